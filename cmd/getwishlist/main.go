@@ -32,6 +32,10 @@ func main() {
 	wishlist.DebugMode = true
 	wishlist.SetProxyURLs(proxyURLs...)
 
+	if err := wishlist.Fetch(); err != nil {
+		log.Fatalln(err)
+	}
+
 	name, err := wishlist.Name()
 	if err != nil {
 		log.Fatalln(err)
@@ -44,7 +48,7 @@ func main() {
 	}
 	fmt.Printf("Printable URL: <%s>\n", printURL)
 
-	items, err := wishlist.Items()
+	items, err := wishlist.ItemList()
 	if err != nil {
 		log.Fatalln(err)
 	}
@@ -0,0 +1,147 @@
+package amazon
+
+import (
+	"strings"
+)
+
+// Contributor is a person or company credited for a product, such as the
+// author of a book or the brand of a gadget.
+type Contributor struct {
+	// Name is who the contributor is, e.g., "Jane Doe" or "Sony".
+	Name string `json:"name"`
+
+	// Role is what the contributor did, e.g., "Author" or "Narrator", or is
+	// empty if not given.
+	Role string `json:"role,omitempty"`
+}
+
+// Byline describes who made a product and in what format, as Amazon shows
+// below its name, e.g., "by Jane Doe (Author), John Roe (Illustrator)
+// (Hardcover)".
+type Byline struct {
+	// Raw is the byline as Amazon shows it.
+	Raw string `json:"raw"`
+
+	// Contributors are the people or companies credited, in the order they
+	// are listed.
+	Contributors []Contributor `json:"contributors,omitempty"`
+
+	// Format is the edition or media of the product, e.g., "Kindle Edition",
+	// "Hardcover" or "Audible Audiobook", or is empty if not given.
+	Format string `json:"format,omitempty"`
+}
+
+// bylinePrefixes are the ways Amazon starts a byline, i.e., "by" in the
+// languages of its stores.
+var bylinePrefixes = []string{"by ", "von ", "de ", "di ", "door ", "av "}
+
+// contributorRoles are the parenthesized roles Amazon credits contributors
+// with, in lowercase, used to tell a contributor's role from a format.
+var contributorRoles = map[string]bool{
+	"author": true, "autor": true, "auteur": true, "autore": true, "auteur(s)": true,
+	"illustrator": true, "illustrateur": true, "illustratore": true, "ilustrador": true,
+	"editor": true, "herausgeber": true, "éditeur": true, "curatore": true,
+	"translator": true, "übersetzer": true, "traducteur": true, "traduttore": true, "traductor": true,
+	"narrator": true, "sprecher": true, "narrateur": true, "narratore": true, "narrador": true,
+	"contributor": true, "foreword": true, "introduction": true, "afterword": true,
+	"photographer": true, "artist": true, "composer": true, "performer": true,
+	"actor": true, "director": true, "producer": true, "creator": true,
+	"adapter": true, "compiler": true, "reader": true, "cover design": true,
+}
+
+// ParseByline parses a byline as Amazon shows it below a product's name, such
+// as "by Jane Doe (Paperback)" or "by Sony". It returns nil if the byline is
+// empty.
+func ParseByline(text string) *Byline {
+	raw := strings.Join(strings.Fields(text), " ")
+	if raw == "" {
+		return nil
+	}
+
+	byline := &Byline{Raw: raw}
+
+	rest := raw
+	lower := strings.ToLower(rest)
+	for _, prefix := range bylinePrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			rest = strings.TrimSpace(rest[len(prefix):])
+			break
+		}
+	}
+
+	if strings.HasSuffix(rest, ")") {
+		if open := strings.LastIndex(rest, "("); open >= 0 {
+			last := strings.TrimSpace(rest[open+1 : len(rest)-1])
+			before := strings.TrimSpace(rest[:open])
+			if strings.HasSuffix(before, ")") || !isContributorRole(last) {
+				byline.Format = last
+				rest = before
+			}
+		}
+	}
+
+	for _, credit := range splitCredits(rest) {
+		if contributor, ok := parseContributor(credit); ok {
+			byline.Contributors = append(byline.Contributors, contributor)
+		}
+	}
+
+	return byline
+}
+
+// isContributorRole reports whether the given parenthesized text credits a
+// contributor, e.g., "Author" or "Author, Illustrator".
+func isContributorRole(text string) bool {
+	for _, role := range strings.Split(text, ",") {
+		if !contributorRoles[strings.ToLower(strings.TrimSpace(role))] {
+			return false
+		}
+	}
+	return true
+}
+
+// splitCredits splits a list of contributors on the commas and "and"s between
+// them, but not those within parentheses. Ampersands are left alone as they
+// usually belong to a company's name.
+func splitCredits(text string) []string {
+	var credits []string
+	depth := 0
+	start := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '(':
+			depth++
+		case ')':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				credits = append(credits, text[start:i])
+				start = i + 1
+			}
+		case ' ':
+			if depth == 0 && strings.HasPrefix(text[i:], " and ") {
+				credits = append(credits, text[start:i])
+				start = i + len(" and ")
+				i = start - 1
+			}
+		}
+	}
+	return append(credits, text[start:])
+}
+
+// parseContributor parses a single credit such as "Jane Doe (Author)".
+func parseContributor(credit string) (Contributor, bool) {
+	credit = strings.TrimSpace(credit)
+	contributor := Contributor{Name: credit}
+
+	if strings.HasSuffix(credit, ")") {
+		if open := strings.LastIndex(credit, "("); open > 0 {
+			contributor.Name = strings.TrimSpace(credit[:open])
+			contributor.Role = strings.TrimSpace(credit[open+1 : len(credit)-1])
+		}
+	}
+
+	return contributor, contributor.Name != ""
+}
@@ -0,0 +1,74 @@
+package amazon
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseByline(t *testing.T) {
+	tests := []struct {
+		text         string
+		contributors []Contributor
+		format       string
+	}{
+		{"by Jane Doe (Paperback)", []Contributor{{Name: "Jane Doe"}}, "Paperback"},
+		{"by Sony", []Contributor{{Name: "Sony"}}, ""},
+		{"by Procter & Gamble", []Contributor{{Name: "Procter & Gamble"}}, ""},
+		{"by Jane Doe (Author)", []Contributor{{Name: "Jane Doe", Role: "Author"}}, ""},
+		{
+			"by Jane Doe (Author), John Roe (Illustrator) (Hardcover)",
+			[]Contributor{{Name: "Jane Doe", Role: "Author"}, {Name: "John Roe", Role: "Illustrator"}},
+			"Hardcover",
+		},
+		{
+			"by Jane Doe and John Roe (Kindle Edition)",
+			[]Contributor{{Name: "Jane Doe"}, {Name: "John Roe"}},
+			"Kindle Edition",
+		},
+		{
+			"by Jane Doe (Author, Narrator)",
+			[]Contributor{{Name: "Jane Doe", Role: "Author, Narrator"}},
+			"",
+		},
+		{
+			"by Jane Doe (Author), Bob Reader (Narrator), Big Audio Co. (Publisher) (Audible Audiobook)",
+			[]Contributor{
+				{Name: "Jane Doe", Role: "Author"},
+				{Name: "Bob Reader", Role: "Narrator"},
+				{Name: "Big Audio Co.", Role: "Publisher"},
+			},
+			"Audible Audiobook",
+		},
+		{"von Erika Mustermann (Autor) (Taschenbuch)", []Contributor{{Name: "Erika Mustermann", Role: "Autor"}}, "Taschenbuch"},
+	}
+
+	for _, test := range tests {
+		byline := ParseByline("\n  " + test.text + "  \n")
+		require.NotNil(t, byline, test.text)
+		require.Equal(t, test.text, byline.Raw)
+		require.Equal(t, test.contributors, byline.Contributors, test.text)
+		require.Equal(t, test.format, byline.Format, test.text)
+	}
+
+	require.Nil(t, ParseByline("  "))
+}
+
+func TestParseWishlistPageByline(t *testing.T) {
+	html := strings.Replace(wishlistHTML, `<span id="item-byline-I2G6UJO0FYWV8J" class="a-size-base"></span>`,
+		`<span id="item-byline-I2G6UJO0FYWV8J" class="a-size-base">by Jane Doe (Paperback)</span>`, 1)
+
+	page, err := ParseWishlistPage(strings.NewReader(html), "https://www.amazon.com/hz/wishlist/ls/3I6EQPZ8OB1DT")
+	require.NoError(t, err)
+
+	item := page.Items["I2G6UJO0FYWV8J"]
+	require.NotNil(t, item.Byline)
+	require.Equal(t, []Contributor{{Name: "Jane Doe"}}, item.Byline.Contributors)
+	require.Equal(t, "Paperback", item.Byline.Format)
+	require.Contains(t, item.String(), "\tby Jane Doe (Paperback)\n")
+
+	page, err = ParseWishlistPage(strings.NewReader(wishlistHTML), "https://www.amazon.com/hz/wishlist/ls/3I6EQPZ8OB1DT")
+	require.NoError(t, err)
+	require.Nil(t, page.Items["I2G6UJO0FYWV8J"].Byline)
+}
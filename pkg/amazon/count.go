@@ -0,0 +1,68 @@
+package amazon
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// countPattern matches a count at the start of text such as "1,234 Used &
+// New", "1 234" or "12K", allowing for the thousands separators of every
+// Amazon store, e.g., "1.234" in Germany, "1 234" with a non-breaking space in
+// France and "1,23,456" in India.
+var countPattern = regexp.MustCompile(`^\(?(\d(?:[\d.,'\s\x{00a0}\x{202f}]*\d)?)\s*([KkMm]?)`)
+
+// countMultipliers are what abbreviated counts such as "12K" are multiplied by.
+var countMultipliers = map[string]float64{
+	"k": 1e3,
+	"m": 1e6,
+}
+
+// parseCount parses a whole number written the way Amazon shows counts, such
+// as of reviews or offers, in any of its stores.
+func parseCount(text string) (int, error) {
+	text = strings.TrimSpace(text)
+	matches := countPattern.FindStringSubmatch(text)
+	if matches == nil {
+		return 0, fmt.Errorf("No count found in '%s'", text)
+	}
+
+	number, suffix := matches[1], strings.ToLower(matches[2])
+	if next, _ := utf8.DecodeRuneInString(text[len(matches[0]):]); suffix != "" && unicode.IsLetter(next) {
+		// The letter begins a word, e.g., "Mal" in "6 Mal", and not an
+		// abbreviation.
+		suffix = ""
+	}
+
+	if suffix == "" {
+		count, err := strconv.Atoi(strings.Map(keepDigits, number))
+		if err != nil {
+			return 0, fmt.Errorf("Could not parse count '%s': %s", text, err)
+		}
+		return count, nil
+	}
+
+	// Abbreviated counts may have a fraction, e.g., "1.2K" or "1,2K", so the
+	// last separator is a decimal point and any others group thousands.
+	whole, fraction := number, ""
+	if i := strings.LastIndexAny(number, ".,"); i >= 0 {
+		whole, fraction = number[:i], number[i+1:]
+	}
+	value, err := strconv.ParseFloat(strings.Map(keepDigits, whole)+"."+strings.Map(keepDigits, fraction), 64)
+	if err != nil {
+		return 0, fmt.Errorf("Could not parse count '%s': %s", text, err)
+	}
+
+	return int(value*countMultipliers[suffix] + 0.5), nil
+}
+
+// keepDigits is a strings.Map function that drops everything but digits.
+func keepDigits(r rune) rune {
+	if isNotDigit(r) {
+		return -1
+	}
+	return r
+}
@@ -0,0 +1,82 @@
+package amazon
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCount(t *testing.T) {
+	tests := []struct {
+		text  string
+		count int
+	}{
+		{"930", 930},
+		{"\n  930\n  ", 930},
+		{"1,234", 1234},
+		{"1.234", 1234},
+		{"1 234", 1234},
+		{"1 234", 1234},
+		{"1 234 évaluations", 1234},
+		{"1'234", 1234},
+		{"1,23,456", 123456},
+		{"(1,234)", 1234},
+		{"12K", 12000},
+		{"1.2K", 1200},
+		{"1,2k", 1200},
+		{"2M", 2000000},
+		{"6 Used & New", 6},
+		{"6 Mal", 6},
+	}
+
+	for _, test := range tests {
+		count, err := parseCount(test.text)
+		require.NoError(t, err, test.text)
+		require.Equal(t, test.count, count, test.text)
+	}
+
+	_, err := parseCount("many")
+	require.Error(t, err)
+}
+
+func TestParseWishlistPageReviewCountFrance(t *testing.T) {
+	html := strings.Replace(wishlistHTML, "\n                                930\n",
+		"\n                                1 234\n", 1)
+
+	page, err := ParseWishlistPage(strings.NewReader(html), "https://www.amazon.fr/hz/wishlist/ls/3I6EQPZ8OB1DT")
+	require.NoError(t, err)
+	require.Equal(t, 1234, page.Items["I2G6UJO0FYWV8J"].ReviewCount)
+	require.Empty(t, page.Warnings)
+}
+
+func TestItemsReviewCountWarning(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hz/wishlist/ls/123abc", func(w http.ResponseWriter, r *http.Request) {
+		html := strings.Replace(wishlistHTML, "\n                                930\n",
+			"\n                                lots\n", 1)
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	wishlist, err := NewWishlistFromIDAtDomain("123abc", ts.URL)
+	require.NoError(t, err)
+	wishlist.CacheResults = false
+
+	items, err := wishlist.Items()
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, 0, items["I2G6UJO0FYWV8J"].ReviewCount)
+	require.NotEmpty(t, items["I2G6UJO0FYWV8J"].ReviewsURL)
+	require.Empty(t, wishlist.Errors())
+
+	warnings := wishlist.Warnings()
+	require.Len(t, warnings, 1)
+	require.Equal(t, "I2G6UJO0FYWV8J", warnings[0].ItemID)
+	require.Equal(t, "ReviewCount", warnings[0].Field)
+	require.Equal(t, wishlist.URLs()[0], warnings[0].URL)
+}
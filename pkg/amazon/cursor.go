@@ -0,0 +1,68 @@
+package amazon
+
+import (
+	"net/url"
+
+	"github.com/gocolly/colly"
+)
+
+const (
+	// lekQueryParam and paginationTokenQueryParam are the query parameters
+	// Amazon uses in the URL of a wishlist's next page to say where it starts.
+	lekQueryParam             = "lek"
+	paginationTokenQueryParam = "paginationToken"
+
+	// pageStartKey is the colly Context key of the position of the first
+	// product on a page.
+	pageStartKey = "pageStart"
+)
+
+// Cursor marks where a crawl of a wishlist stopped because it reached
+// MaxPages or MaxItems, so that a later crawl can resume from there.
+type Cursor struct {
+	// URL is the address of the page of the wishlist to resume from.
+	URL string
+
+	// LEK is Amazon's "lek" pagination parameter in URL, or empty if URL has
+	// none.
+	LEK string
+
+	// PaginationToken is Amazon's "paginationToken" pagination parameter in
+	// URL, or empty if URL has none.
+	PaginationToken string
+
+	// Position is the position in the wishlist of the first product on the
+	// page at URL.
+	Position int
+
+	// Skip is how many products at the start of the page at URL were already
+	// loaded, and so are skipped when resuming.
+	Skip int
+}
+
+// newCursor returns a cursor for resuming a crawl at the given page, skipping
+// the given number of products at its start.
+func newCursor(pageURL string, position int, skip int) *Cursor {
+	cursor := &Cursor{URL: pageURL, Position: position, Skip: skip}
+	if uri, err := url.Parse(pageURL); err == nil {
+		query := uri.Query()
+		cursor.LEK = query.Get(lekQueryParam)
+		cursor.PaginationToken = query.Get(paginationTokenQueryParam)
+	}
+	return cursor
+}
+
+// pageStart returns the position of the first product on a page.
+func pageStart(ctx *colly.Context) int {
+	position, _ := ctx.GetAny(pageStartKey).(int)
+	return position
+}
+
+// newPageContext returns the colly Context for requesting a page whose first
+// product is at the given position.
+func newPageContext(position int) *colly.Context {
+	ctx := colly.NewContext()
+	ctx.Put(positionKey, position)
+	ctx.Put(pageStartKey, position)
+	return ctx
+}
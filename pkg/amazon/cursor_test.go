@@ -0,0 +1,107 @@
+package amazon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemListMaxPages(t *testing.T) {
+	id := "123abc"
+	ts := newPagedTestServer(t, id, []string{"I2G6UJO0FYWV8J"}, []string{"I1BXZQ7XJ0DSLV"}, []string{"I3K2PLM9XQ0AAB"})
+	defer ts.Close()
+
+	wishlist, err := NewWishlistFromIDAtDomain(id, ts.URL)
+	require.NoError(t, err)
+	wishlist.CacheResults = false
+	wishlist.MaxPages = 2
+
+	items, err := wishlist.ItemList()
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	require.Len(t, wishlist.URLs(), 2)
+
+	cursor := wishlist.NextCursor()
+	require.Equal(t, &Cursor{
+		URL:             ts.URL + "/hz/wishlist/ls/123abc?lek=2&paginationToken=token2",
+		LEK:             "2",
+		PaginationToken: "token2",
+		Position:        2,
+	}, cursor)
+
+	require.NoError(t, wishlist.Resume(cursor))
+	items, err = wishlist.ItemList()
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, "I3K2PLM9XQ0AAB", items[0].ID)
+	require.Equal(t, 2, items[0].Position)
+	require.Nil(t, wishlist.NextCursor())
+}
+
+func TestItemListMaxItems(t *testing.T) {
+	id := "123abc"
+	ts := newPagedTestServer(t, id, []string{"I2G6UJO0FYWV8J", "I1BXZQ7XJ0DSLV"}, []string{"I3K2PLM9XQ0AAB"})
+	defer ts.Close()
+
+	wishlist, err := NewWishlistFromIDAtDomain(id, ts.URL)
+	require.NoError(t, err)
+	wishlist.CacheResults = false
+	wishlist.MaxItems = 1
+
+	items, err := wishlist.ItemList()
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, "I2G6UJO0FYWV8J", items[0].ID)
+	require.Len(t, wishlist.URLs(), 1)
+
+	cursor := wishlist.NextCursor()
+	require.NotNil(t, cursor)
+	require.Equal(t, wishlist.URLs()[0], cursor.URL)
+	require.Equal(t, 0, cursor.Position)
+	require.Equal(t, 1, cursor.Skip)
+
+	wishlist.MaxItems = 0
+	require.NoError(t, wishlist.Resume(cursor))
+	items, err = wishlist.ItemList()
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	require.Equal(t, "I1BXZQ7XJ0DSLV", items[0].ID)
+	require.Equal(t, 1, items[0].Position)
+	require.Equal(t, "I3K2PLM9XQ0AAB", items[1].ID)
+	require.Equal(t, 2, items[1].Position)
+	require.Nil(t, wishlist.NextCursor())
+}
+
+func TestItemListMaxItemsAtEndOfPage(t *testing.T) {
+	id := "123abc"
+	ts := newPagedTestServer(t, id, []string{"I2G6UJO0FYWV8J", "I1BXZQ7XJ0DSLV"}, []string{"I3K2PLM9XQ0AAB"})
+	defer ts.Close()
+
+	wishlist, err := NewWishlistFromIDAtDomain(id, ts.URL)
+	require.NoError(t, err)
+	wishlist.CacheResults = false
+	wishlist.MaxItems = 2
+
+	items, err := wishlist.ItemList()
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	cursor := wishlist.NextCursor()
+	require.NotNil(t, cursor)
+	require.Equal(t, "1", cursor.LEK)
+	require.Equal(t, 2, cursor.Position)
+	require.Equal(t, 0, cursor.Skip)
+}
+
+func TestWishlistResumeInvalid(t *testing.T) {
+	wishlist, err := NewWishlistFromID("123abc")
+	require.NoError(t, err)
+
+	require.Error(t, wishlist.Resume(&Cursor{URL: "/hz/wishlist/ls/123abc?lek=2"}))
+
+	require.NoError(t, wishlist.Resume(&Cursor{URL: "https://www.amazon.com/hz/wishlist/ls/123abc?lek=2"}))
+	require.Equal(t, []string{"https://www.amazon.com/hz/wishlist/ls/123abc?lek=2"}, wishlist.URLs())
+
+	require.NoError(t, wishlist.Resume(nil))
+	require.Contains(t, wishlist.URLs()[0], "reveal=unpurchased")
+}
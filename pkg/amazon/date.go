@@ -0,0 +1,170 @@
+package amazon
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// monthNames maps the primary subtag of a language to the names and
+// abbreviations of months in it, as Amazon writes them.
+var monthNames = map[string]map[string]time.Month{
+	"en": {
+		"jan": time.January, "feb": time.February, "mar": time.March,
+		"apr": time.April, "jun": time.June, "jul": time.July,
+		"aug": time.August, "sep": time.September, "sept": time.September,
+		"oct": time.October, "nov": time.November, "dec": time.December,
+	},
+	"de": {
+		"januar": time.January, "jänner": time.January, "jan": time.January,
+		"februar": time.February, "feb": time.February,
+		"märz": time.March, "mär": time.March, "maerz": time.March,
+		"april": time.April, "apr": time.April,
+		"mai":  time.May,
+		"juni": time.June, "jun": time.June,
+		"juli": time.July, "jul": time.July,
+		"august": time.August, "aug": time.August,
+		"september": time.September, "sep": time.September, "sept": time.September,
+		"oktober": time.October, "okt": time.October,
+		"november": time.November, "nov": time.November,
+		"dezember": time.December, "dez": time.December,
+	},
+	"fr": {
+		"janvier": time.January, "janv": time.January,
+		"février": time.February, "fevrier": time.February, "févr": time.February,
+		"mars":  time.March,
+		"avril": time.April, "avr": time.April,
+		"mai":     time.May,
+		"juin":    time.June,
+		"juillet": time.July, "juil": time.July,
+		"août": time.August, "aout": time.August,
+		"septembre": time.September, "sept": time.September,
+		"octobre": time.October, "oct": time.October,
+		"novembre": time.November, "nov": time.November,
+		"décembre": time.December, "decembre": time.December, "déc": time.December,
+	},
+	"it": {
+		"gennaio": time.January, "gen": time.January,
+		"febbraio": time.February, "feb": time.February,
+		"marzo": time.March, "mar": time.March,
+		"aprile": time.April, "apr": time.April,
+		"maggio": time.May, "mag": time.May,
+		"giugno": time.June, "giu": time.June,
+		"luglio": time.July, "lug": time.July,
+		"agosto": time.August, "ago": time.August,
+		"settembre": time.September, "set": time.September,
+		"ottobre": time.October, "ott": time.October,
+		"novembre": time.November, "nov": time.November,
+		"dicembre": time.December, "dic": time.December,
+	},
+	"es": {
+		"enero": time.January, "ene": time.January,
+		"febrero": time.February, "feb": time.February,
+		"marzo": time.March, "mar": time.March,
+		"abril": time.April, "abr": time.April,
+		"mayo": time.May, "may": time.May,
+		"junio": time.June, "jun": time.June,
+		"julio": time.July, "jul": time.July,
+		"agosto": time.August, "ago": time.August,
+		"septiembre": time.September, "setiembre": time.September, "sept": time.September, "sep": time.September,
+		"octubre": time.October, "oct": time.October,
+		"noviembre": time.November, "nov": time.November,
+		"diciembre": time.December, "dic": time.December,
+	},
+	"pt": {
+		"janeiro": time.January, "jan": time.January,
+		"fevereiro": time.February, "fev": time.February,
+		"março": time.March, "marco": time.March, "mar": time.March,
+		"abril": time.April, "abr": time.April,
+		"maio": time.May, "mai": time.May,
+		"junho": time.June, "jun": time.June,
+		"julho": time.July, "jul": time.July,
+		"agosto": time.August, "ago": time.August,
+		"setembro": time.September, "set": time.September,
+		"outubro": time.October, "out": time.October,
+		"novembro": time.November, "nov": time.November,
+		"dezembro": time.December, "dez": time.December,
+	},
+	"nl": {
+		"januari": time.January, "jan": time.January,
+		"februari": time.February, "feb": time.February,
+		"maart": time.March, "mrt": time.March,
+		"april": time.April, "apr": time.April,
+		"mei":  time.May,
+		"juni": time.June, "jun": time.June,
+		"juli": time.July, "jul": time.July,
+		"augustus": time.August, "aug": time.August,
+		"september": time.September, "sep": time.September, "sept": time.September,
+		"oktober": time.October, "okt": time.October,
+		"november": time.November, "nov": time.November,
+		"december": time.December, "dec": time.December,
+	},
+	"sv": {
+		"januari": time.January, "jan": time.January,
+		"februari": time.February, "feb": time.February,
+		"mars": time.March, "mar": time.March,
+		"april": time.April, "apr": time.April,
+		"maj":  time.May,
+		"juni": time.June, "jun": time.June,
+		"juli": time.July, "jul": time.July,
+		"augusti": time.August, "aug": time.August,
+		"september": time.September, "sep": time.September, "sept": time.September,
+		"oktober": time.October, "okt": time.October,
+		"november": time.November, "nov": time.November,
+		"december": time.December, "dec": time.December,
+	},
+}
+
+// ordinalSuffixes are written after the day of the month in some languages,
+// e.g., "1er juillet" in French or "1º de julio" in Spanish.
+var ordinalSuffixes = []string{"er", "º", "°", "ª"}
+
+// ParseDate parses a date written the way this store shows when a product was
+// added to a wishlist, e.g., "Hinzugefügt am 10. Juli 2019" or just
+// "10. Juli 2019" at amazon.de.
+func (m *Marketplace) ParseDate(text string) (time.Time, error) {
+	raw := m.trimDateAdded(text)
+	if raw == "" {
+		return time.Time{}, fmt.Errorf("No date found in '%s'", text)
+	}
+
+	date, err := time.Parse(m.DateLayout, m.englishDate(raw))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Could not parse date '%s' for %s: %s", raw, m.Domain, err)
+	}
+
+	return date, nil
+}
+
+// englishDate rewrites the month names in the given date in English, as
+// understood by time.Parse, and drops any ordinal suffixes from the day.
+func (m *Marketplace) englishDate(date string) string {
+	language := strings.ToLower(strings.SplitN(m.Language, "-", 2)[0])
+	months := monthNames[language]
+
+	words := strings.Fields(date)
+	for i, word := range words {
+		core := strings.TrimRight(word, ".,")
+		trailer := strings.TrimPrefix(word[len(core):], ".")
+
+		if month, ok := months[strings.ToLower(core)]; ok {
+			words[i] = month.String() + trailer
+			continue
+		}
+
+		for _, suffix := range ordinalSuffixes {
+			day := strings.TrimSuffix(core, suffix)
+			if day != core && day != "" && strings.IndexFunc(day, isNotDigit) < 0 {
+				words[i] = day + word[len(core):]
+				break
+			}
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+func isNotDigit(r rune) bool {
+	return !unicode.IsDigit(r)
+}
@@ -0,0 +1,78 @@
+package amazon
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarketplaceParseDate(t *testing.T) {
+	tests := []struct {
+		domain string
+		text   string
+		year   int
+		month  time.Month
+		day    int
+	}{
+		{"amazon.com", "Added July 10, 2019", 2019, time.July, 10},
+		{"amazon.com", "October 20, 2019", 2019, time.October, 20},
+		{"amazon.ca", "Added December 1, 2018", 2018, time.December, 1},
+		{"amazon.co.uk", "Added 10 July 2019", 2019, time.July, 10},
+		{"amazon.co.uk", "10 Sept 2019", 2019, time.September, 10},
+		{"amazon.com.au", "Added 3 February 2020", 2020, time.February, 3},
+		{"amazon.in", "Added 25 March 2019", 2019, time.March, 25},
+		{"amazon.de", "Hinzugefügt am 10. Juli 2019", 2019, time.July, 10},
+		{"amazon.de", "3. März 2020", 2020, time.March, 3},
+		{"amazon.de", "Hinzugefügt am 24. Dezember 2018", 2018, time.December, 24},
+		{"amazon.fr", "Ajouté le 10 juillet 2019", 2019, time.July, 10},
+		{"amazon.fr", "Ajouté le 1er août 2019", 2019, time.August, 1},
+		{"amazon.fr", "5 févr. 2020", 2020, time.February, 5},
+		{"amazon.it", "Aggiunto il 10 luglio 2019", 2019, time.July, 10},
+		{"amazon.es", "Añadido el 10 de julio de 2019", 2019, time.July, 10},
+		{"amazon.es", "1º de septiembre de 2019", 2019, time.September, 1},
+		{"amazon.com.mx", "Agregado el 15 de enero de 2020", 2020, time.January, 15},
+		{"amazon.com.br", "Adicionado em 10 de julho de 2019", 2019, time.July, 10},
+		{"amazon.com.br", "2 de março de 2020", 2020, time.March, 2},
+		{"amazon.nl", "Toegevoegd op 10 juli 2019", 2019, time.July, 10},
+		{"amazon.nl", "Toegevoegd op 6 mei 2020", 2020, time.May, 6},
+		{"amazon.se", "Tillagd 10 juli 2019", 2019, time.July, 10},
+		{"amazon.se", "Tillagd 9 maj 2020", 2020, time.May, 9},
+		{"amazon.co.jp", "2019年7月10日に追加", 2019, time.July, 10},
+		{"amazon.co.jp", "2020年12月1日", 2020, time.December, 1},
+	}
+
+	for _, test := range tests {
+		m, ok := LookupMarketplace(test.domain)
+		require.True(t, ok, test.domain)
+
+		date, err := m.ParseDate(test.text)
+		require.NoError(t, err, "%s: %s", test.domain, test.text)
+		require.Equal(t, time.Date(test.year, test.month, test.day, 0, 0, 0, 0, time.UTC), date,
+			"%s: %s", test.domain, test.text)
+	}
+}
+
+func TestMarketplaceParseDateInvalid(t *testing.T) {
+	m, _ := LookupMarketplace("amazon.de")
+
+	_, err := m.ParseDate("Hinzugefügt am ")
+	require.Error(t, err)
+
+	_, err = m.ParseDate("10. Brumaire 2019")
+	require.Error(t, err)
+}
+
+func TestParseWishlistPageGermany(t *testing.T) {
+	html := strings.Replace(wishlistHTML, "Added July 10, 2019", "Hinzugefügt am 10. Juli 2019", 1)
+
+	page, err := ParseWishlistPage(strings.NewReader(html), "https://www.amazon.de/hz/wishlist/ls/3I6EQPZ8OB1DT")
+	require.NoError(t, err)
+
+	item := page.Items["I2G6UJO0FYWV8J"]
+	require.Equal(t, "10. Juli 2019", item.RawDateAdded)
+	dateAdded, err := item.DateAdded()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2019, 7, 10, 0, 0, 0, 0, time.UTC), *dateAdded)
+}
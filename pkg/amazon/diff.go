@@ -0,0 +1,181 @@
+package amazon
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Diff describes how the products on a wishlist changed between two loads of
+// it, such as yesterday's Items and today's.
+type Diff struct {
+	// Added are the products only in the newer load, in the order they are
+	// listed in it.
+	Added []*Item
+
+	// Removed are the products only in the older load, in the order they were
+	// listed in it.
+	Removed []*Item
+
+	// Changed are the products in both loads whose details differ, in the
+	// order they are listed in the newer load.
+	Changed []*ItemChange
+}
+
+// ItemChange describes how the details of a product changed between two loads
+// of a wishlist.
+type ItemChange struct {
+	// Old is the product as it was in the older load.
+	Old *Item
+
+	// New is the product as it is in the newer load.
+	New *Item
+
+	// Fields are the details that changed.
+	Fields []FieldChange
+}
+
+// FieldChange describes how a detail of a product changed.
+type FieldChange struct {
+	// Field is the name of the detail, which is the name of the Item field it
+	// comes from, or "Available" for whether the product can be added to your
+	// cart, i.e., has an Offer.
+	Field string
+
+	// Old is the detail's value in the older load, e.g., a *Money for
+	// "PriceAmount" or an int for "ReviewCount".
+	Old interface{}
+
+	// New is the detail's value in the newer load, of the same type as Old.
+	New interface{}
+}
+
+// DiffItems compares two loads of a wishlist's products, such as those
+// returned by Items, and reports which products were added, removed, or had
+// their price, requested or owned counts, rating, review count, Prime
+// eligibility or availability change.
+func DiffItems(oldItems map[string]*Item, newItems map[string]*Item) *Diff {
+	diff := &Diff{}
+
+	for _, item := range sortItems(newItems) {
+		old, ok := oldItems[item.ID]
+		if !ok {
+			diff.Added = append(diff.Added, item)
+			continue
+		}
+
+		if fields := diffFields(old, item); len(fields) > 0 {
+			diff.Changed = append(diff.Changed, &ItemChange{Old: old, New: item, Fields: fields})
+		}
+	}
+
+	for _, item := range sortItems(oldItems) {
+		if _, ok := newItems[item.ID]; !ok {
+			diff.Removed = append(diff.Removed, item)
+		}
+	}
+
+	return diff
+}
+
+// IsEmpty reports whether nothing changed.
+func (d *Diff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+func (d *Diff) String() string {
+	var sb strings.Builder
+
+	for _, item := range d.Added {
+		sb.WriteString("+ ")
+		sb.WriteString(item.Name)
+		sb.WriteString("\n")
+	}
+
+	for _, item := range d.Removed {
+		sb.WriteString("- ")
+		sb.WriteString(item.Name)
+		sb.WriteString("\n")
+	}
+
+	for _, change := range d.Changed {
+		sb.WriteString("~ ")
+		sb.WriteString(change.New.Name)
+		sb.WriteString("\n")
+		for _, field := range change.Fields {
+			sb.WriteString("\t")
+			sb.WriteString(field.String())
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// Field returns the change to the detail with the given name, such as
+// "PriceAmount". The second return value is false if it didn't change.
+func (c *ItemChange) Field(name string) (FieldChange, bool) {
+	for _, field := range c.Fields {
+		if field.Field == name {
+			return field, true
+		}
+	}
+	return FieldChange{}, false
+}
+
+func (c FieldChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Field, formatFieldValue(c.Old), formatFieldValue(c.New))
+}
+
+// formatFieldValue returns a detail's value as shown in a FieldChange.
+func formatFieldValue(value interface{}) string {
+	if money, ok := value.(*Money); ok {
+		if money == nil {
+			return "none"
+		}
+		return money.String()
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// diffFields returns the details that differ between two loads of a product.
+func diffFields(old *Item, item *Item) []FieldChange {
+	var fields []FieldChange
+	add := func(field string, oldValue, newValue interface{}) {
+		fields = append(fields, FieldChange{Field: field, Old: oldValue, New: newValue})
+	}
+
+	if !sameMoney(old.PriceAmount, item.PriceAmount) {
+		add("PriceAmount", old.PriceAmount, item.PriceAmount)
+	} else if old.PriceAmount == nil && old.Price != item.Price {
+		add("Price", old.Price, item.Price)
+	}
+	if old.RequestedCount != item.RequestedCount {
+		add("RequestedCount", old.RequestedCount, item.RequestedCount)
+	}
+	if old.OwnedCount != item.OwnedCount {
+		add("OwnedCount", old.OwnedCount, item.OwnedCount)
+	}
+	if old.Stars != item.Stars {
+		add("Stars", old.Stars, item.Stars)
+	}
+	if old.ReviewCount != item.ReviewCount {
+		add("ReviewCount", old.ReviewCount, item.ReviewCount)
+	}
+	if old.IsPrime != item.IsPrime {
+		add("IsPrime", old.IsPrime, item.IsPrime)
+	}
+	if wasAvailable, isAvailable := old.Offer != nil, item.Offer != nil; wasAvailable != isAvailable {
+		add("Available", wasAvailable, isAvailable)
+	}
+
+	return fields
+}
+
+// sameMoney reports whether two amounts of money, either of which may be
+// nil, are the same.
+func sameMoney(a *Money, b *Money) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,97 @@
+package amazon
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newDiffTestItem(id string, position int) *Item {
+	item := NewItem(id, "Product "+id, "https://www.amazon.com/dp/"+id)
+	item.Position = position
+	item.Price = "$10.00"
+	item.PriceAmount = &Money{Amount: 1000, Currency: "USD"}
+	item.RequestedCount = 1
+	item.OwnedCount = 0
+	item.Stars = 4
+	item.ReviewCount = 12
+	item.Offer = &Offer{}
+	return item
+}
+
+func TestDiffItems(t *testing.T) {
+	oldItems := map[string]*Item{
+		"A": newDiffTestItem("A", 0),
+		"B": newDiffTestItem("B", 1),
+		"C": newDiffTestItem("C", 2),
+	}
+
+	changed := newDiffTestItem("B", 1)
+	changed.Price = "$8.50"
+	changed.PriceAmount = &Money{Amount: 850, Currency: "USD"}
+	changed.OwnedCount = 1
+	changed.Stars = 4.5
+	changed.ReviewCount = 13
+	changed.IsPrime = true
+	changed.Offer = nil
+
+	newItems := map[string]*Item{
+		"D": newDiffTestItem("D", 0),
+		"B": changed,
+		"C": newDiffTestItem("C", 2),
+	}
+
+	diff := DiffItems(oldItems, newItems)
+	require.False(t, diff.IsEmpty())
+	require.Equal(t, []*Item{newItems["D"]}, diff.Added)
+	require.Equal(t, []*Item{oldItems["A"]}, diff.Removed)
+	require.Len(t, diff.Changed, 1)
+
+	change := diff.Changed[0]
+	require.Equal(t, oldItems["B"], change.Old)
+	require.Equal(t, changed, change.New)
+	require.Equal(t, []FieldChange{
+		{Field: "PriceAmount", Old: &Money{Amount: 1000, Currency: "USD"}, New: &Money{Amount: 850, Currency: "USD"}},
+		{Field: "OwnedCount", Old: 0, New: 1},
+		{Field: "Stars", Old: 4.0, New: 4.5},
+		{Field: "ReviewCount", Old: 12, New: 13},
+		{Field: "IsPrime", Old: false, New: true},
+		{Field: "Available", Old: true, New: false},
+	}, change.Fields)
+
+	field, ok := change.Field("OwnedCount")
+	require.True(t, ok)
+	require.Equal(t, 1, field.New)
+	_, ok = change.Field("RequestedCount")
+	require.False(t, ok)
+
+	require.Equal(t, "PriceAmount: 10.00 USD -> 8.50 USD", change.Fields[0].String())
+	require.True(t, strings.HasPrefix(diff.String(), "+ Product D\n- Product A\n~ Product B\n\tPriceAmount: 10.00 USD -> 8.50 USD\n"))
+}
+
+func TestDiffItemsUnchanged(t *testing.T) {
+	items := map[string]*Item{"A": newDiffTestItem("A", 0)}
+	same := map[string]*Item{"A": newDiffTestItem("A", 0)}
+
+	diff := DiffItems(items, same)
+	require.True(t, diff.IsEmpty())
+	require.Equal(t, "", diff.String())
+}
+
+func TestDiffItemsPriceWithoutAmount(t *testing.T) {
+	old := newDiffTestItem("A", 0)
+	old.Price = "Currently unavailable"
+	old.PriceAmount = nil
+	item := newDiffTestItem("A", 0)
+	item.PriceAmount = nil
+
+	diff := DiffItems(map[string]*Item{"A": old}, map[string]*Item{"A": item})
+	require.Len(t, diff.Changed, 1)
+	require.Equal(t, []FieldChange{{Field: "Price", Old: "Currently unavailable", New: "$10.00"}},
+		diff.Changed[0].Fields)
+
+	item.PriceAmount = &Money{Amount: 1000, Currency: "USD"}
+	diff = DiffItems(map[string]*Item{"A": old}, map[string]*Item{"A": item})
+	require.Equal(t, "PriceAmount: none -> 10.00 USD", diff.Changed[0].Fields[0].String())
+}
@@ -0,0 +1,168 @@
+package amazon
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var (
+	// ErrRobotCheck indicates Amazon showed a robot check or captcha instead
+	// of the wishlist.
+	ErrRobotCheck = errors.New("Amazon is not showing the wishlist because it thinks I'm a robot :(")
+
+	// ErrNotFound indicates there is no wishlist at the requested URL.
+	ErrNotFound = errors.New("Amazon wishlist not found")
+
+	// ErrPrivate indicates the wishlist is private, so Amazon redirected to
+	// its sign-in page instead of showing it.
+	ErrPrivate = errors.New("Amazon wishlist is private or requires signing in")
+
+	// ErrUnexpectedLayout indicates Amazon returned a page that does not look
+	// like a wishlist, so no products could be found on it.
+	ErrUnexpectedLayout = errors.New("Amazon wishlist page does not have the expected layout")
+
+	// ErrRateLimited indicates Amazon is refusing requests because too many
+	// have been made.
+	ErrRateLimited = errors.New("Amazon is limiting how often the wishlist can be requested")
+)
+
+// RequestError describes a problem loading a particular page of a wishlist.
+// Use errors.Is to check for ErrRobotCheck, ErrNotFound, ErrPrivate,
+// ErrUnexpectedLayout or ErrRateLimited.
+type RequestError struct {
+	// URL is the address of the page that could not be loaded.
+	URL string
+
+	// StatusCode is the HTTP status code Amazon responded with, or 0 if
+	// unknown, such as when no response was received.
+	StatusCode int
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *RequestError) Error() string {
+	if e.StatusCode > 0 {
+		return fmt.Sprintf("%s (status %d from %s)", e.Err, e.StatusCode, e.URL)
+	}
+	return fmt.Sprintf("%s (%s)", e.Err, e.URL)
+}
+
+// Unwrap returns the underlying error.
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// FieldError describes a detail of a product on a wishlist page that could not
+// be parsed.
+type FieldError struct {
+	// URL is the address of the page the product is on.
+	URL string
+
+	// ItemID is the identifier of the product.
+	ItemID string
+
+	// Field is the name of the Item field that could not be set.
+	Field string
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("Could not parse %s of item %s on %s: %s", e.Field, e.ItemID, e.URL, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// MultiError collects every problem that occurred while loading a wishlist.
+// It is returned when a Wishlist's PartialResults is set.
+type MultiError struct {
+	// Errors are the problems that occurred, in the order they were found.
+	Errors []error
+}
+
+func (e *MultiError) Error() string {
+	if len(e.Errors) == 1 {
+		return e.Errors[0].Error()
+	}
+
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%d errors occurred loading the wishlist:\n\t* %s",
+		len(e.Errors), strings.Join(messages, "\n\t* "))
+}
+
+// Is reports whether any of the collected errors matches the target.
+func (e *MultiError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first of the collected errors that matches the target, and if
+// so, sets the target to that error.
+func (e *MultiError) As(target interface{}) bool {
+	for _, err := range e.Errors {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// responseError wraps an error from requesting the given URL, classifying it
+// by the HTTP status code Amazon responded with. Robot checks are sometimes
+// served with an error status, so the body is checked for one first.
+func responseError(m *Marketplace, pageURL string, statusCode int, body []byte, err error) *RequestError {
+	if doc, docErr := goquery.NewDocumentFromReader(bytes.NewReader(body)); docErr == nil && isRobotCheckPage(m, doc.Selection) {
+		return &RequestError{URL: pageURL, StatusCode: statusCode, Err: ErrRobotCheck}
+	}
+
+	switch statusCode {
+	case http.StatusNotFound, http.StatusGone:
+		err = ErrNotFound
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		err = ErrRateLimited
+	}
+
+	return &RequestError{URL: pageURL, StatusCode: statusCode, Err: err}
+}
+
+// pageError returns an error if the given page is not a wishlist, such as
+// when Amazon showed a robot check or sign-in page instead.
+func pageError(m *Marketplace, pageURL *url.URL, statusCode int, page *goquery.Selection) error {
+	var err error
+	if isRobotCheckPage(m, page) {
+		err = ErrRobotCheck
+	} else if strings.HasPrefix(pageURL.Path, signInPathPrefix) {
+		err = ErrPrivate
+	} else if page.Find(itemListSelector).Length() < 1 {
+		err = ErrUnexpectedLayout
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	return &RequestError{URL: pageURL.String(), StatusCode: statusCode, Err: err}
+}
+
+// isRobotCheckPage reports whether the given page is a robot check or captcha.
+func isRobotCheckPage(m *Marketplace, page *goquery.Selection) bool {
+	return m.isRobotCheck(page.Text()) || page.Find(captchaSelector).Length() > 0
+}
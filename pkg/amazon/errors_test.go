@@ -0,0 +1,170 @@
+package amazon
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemsRobotCheck(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(robotHTML))
+	}))
+	defer ts.Close()
+
+	err := fetchTestWishlist(t, ts.URL)
+	require.True(t, errors.Is(err, ErrRobotCheck), "should be a robot check error: %v", err)
+
+	var reqErr *RequestError
+	require.True(t, errors.As(err, &reqErr))
+	require.Equal(t, http.StatusOK, reqErr.StatusCode)
+	require.Contains(t, reqErr.URL, ts.URL+"/hz/wishlist/ls/123abc")
+}
+
+func TestItemsRobotCheckServiceUnavailable(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(robotHTML))
+	}))
+	defer ts.Close()
+
+	err := fetchTestWishlist(t, ts.URL)
+	require.True(t, errors.Is(err, ErrRobotCheck), "should be a robot check error: %v", err)
+	require.False(t, errors.Is(err, ErrRateLimited), "should not be a rate limit error: %v", err)
+
+	var reqErr *RequestError
+	require.True(t, errors.As(err, &reqErr))
+	require.Equal(t, http.StatusServiceUnavailable, reqErr.StatusCode)
+}
+
+func TestItemsNotFound(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	err := fetchTestWishlist(t, ts.URL)
+	require.True(t, errors.Is(err, ErrNotFound), "should be a not found error: %v", err)
+
+	var reqErr *RequestError
+	require.True(t, errors.As(err, &reqErr))
+	require.Equal(t, http.StatusNotFound, reqErr.StatusCode)
+}
+
+func TestItemsRateLimited(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	err := fetchTestWishlist(t, ts.URL)
+	require.True(t, errors.Is(err, ErrRateLimited), "should be a rate limited error: %v", err)
+
+	var reqErr *RequestError
+	require.True(t, errors.As(err, &reqErr))
+	require.Equal(t, http.StatusServiceUnavailable, reqErr.StatusCode)
+}
+
+func TestItemsPrivate(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hz/wishlist/ls/123abc", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ap/signin?openid.return_to=wishlist", http.StatusFound)
+	})
+	mux.HandleFunc("/ap/signin", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html><body><h1>Sign-In</h1></body></html>"))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	err := fetchTestWishlist(t, ts.URL)
+	require.True(t, errors.Is(err, ErrPrivate), "should be a private list error: %v", err)
+
+	var reqErr *RequestError
+	require.True(t, errors.As(err, &reqErr))
+	require.Contains(t, reqErr.URL, ts.URL+"/ap/signin")
+}
+
+func TestItemsUnexpectedLayout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html><body><h1>Something else entirely</h1></body></html>"))
+	}))
+	defer ts.Close()
+
+	err := fetchTestWishlist(t, ts.URL)
+	require.True(t, errors.Is(err, ErrUnexpectedLayout), "should be a layout error: %v", err)
+}
+
+func TestParseWishlistPageRobotCheck(t *testing.T) {
+	_, err := ParseWishlistPage(strings.NewReader(robotHTML), "https://www.amazon.com/hz/wishlist/ls/123abc")
+	require.True(t, errors.Is(err, ErrRobotCheck), "should be a robot check error: %v", err)
+}
+
+func TestItemsPartialResults(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hz/wishlist/ls/123abc", func(w http.ResponseWriter, r *http.Request) {
+		var html string
+		switch r.URL.Query().Get("lek") {
+		case "":
+			html = wishlistPageHTML([]string{"I2G6UJO0FYWV8J"}, "/hz/wishlist/ls/123abc?lek=2")
+		case "2":
+			html = wishlistPageHTML([]string{"I1BXZQ7XJ0DSLV"}, "/hz/wishlist/ls/123abc?lek=3")
+			html = strings.Replace(html, ">50</span>", ">fifty</span>", 1)
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	wishlist, err := NewWishlistFromIDAtDomain("123abc", ts.URL)
+	require.NoError(t, err)
+	wishlist.CacheResults = false
+
+	items, err := wishlist.Items()
+	require.Error(t, err)
+	require.Nil(t, items)
+
+	wishlist.PartialResults = true
+	items, err = wishlist.Items()
+	require.Len(t, items, 2)
+	require.Equal(t, -1, items["I1BXZQ7XJ0DSLV"].RequestedCount)
+	require.Equal(t, 11, items["I1BXZQ7XJ0DSLV"].OwnedCount)
+
+	var multiErr *MultiError
+	require.True(t, errors.As(err, &multiErr))
+	require.Len(t, multiErr.Errors, 2)
+	require.True(t, errors.Is(err, ErrNotFound))
+
+	var fieldErr *FieldError
+	require.True(t, errors.As(err, &fieldErr))
+	require.Equal(t, "I1BXZQ7XJ0DSLV", fieldErr.ItemID)
+	require.Equal(t, "RequestedCount", fieldErr.Field)
+	require.Equal(t, ts.URL+"/hz/wishlist/ls/123abc?lek=2", fieldErr.URL)
+	require.Contains(t, err.Error(), "2 errors occurred")
+}
+
+const robotHTML = `<!doctype html>
+<html>
+	<body>
+		<h4>Enter the characters you see below</h4>
+		<p class="a-last">Sorry, we just need to make sure you're not a robot. For best results, please make sure your browser is accepting cookies.</p>
+		<form method="get" action="/errors/validateCaptcha" name=""></form>
+	</body>
+</html>`
+
+func fetchTestWishlist(t *testing.T, domain string) error {
+	wishlist, err := NewWishlistFromIDAtDomain("123abc", domain)
+	require.NoError(t, err)
+	wishlist.CacheResults = false
+
+	return wishlist.Fetch()
+}
@@ -2,11 +2,22 @@ package amazon
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 )
 
+// Attribute is a detail of the particular variation of a product the wishlist
+// recipient wants, such as its size or color.
+type Attribute struct {
+	// Name is what the detail is, e.g., "Size".
+	Name string `json:"name"`
+
+	// Value is the variation wanted, e.g., "Medium".
+	Value string `json:"value"`
+}
+
 // Item represents a product on an Amazon wishlist.
 type Item struct {
 	// IsPrime indicates whether the product is eligible for Amazon Prime free
@@ -36,22 +47,79 @@ type Item struct {
 	// OwnedCount is how many of the product the wishlist recipient already owns.
 	OwnedCount int
 
+	// Purchased indicates whether this product has already been bought, i.e.,
+	// the wishlist recipient owns as many as they requested, or it was loaded
+	// with RevealPurchased.
+	Purchased bool
+
+	// Priority is how much the wishlist recipient wants this product.
+	Priority Priority
+
+	// Comment is the wishlist recipient's note about this product, e.g.,
+	// "size M please".
+	Comment string
+
+	// Attributes describe the particular variation of this product the
+	// wishlist recipient wants, such as its size or style, in the order
+	// Amazon lists them.
+	Attributes []Attribute
+
 	// Name is the name of this product.
 	Name string
 
+	// Byline describes who made this product and in what format, or is nil if
+	// Amazon doesn't say.
+	Byline *Byline
+
 	// Price is a string representation of the cost of this product on Amazon.
 	Price string
 
+	// PriceAmount is the cost of this product on Amazon, or nil if it is not
+	// known. When Price is a range, such as "$10.99 - $24.99", this is the
+	// low end of it.
+	PriceAmount *Money
+
+	// MaxPriceAmount is the high end of the range when Price is a range, and
+	// otherwise the same as PriceAmount.
+	MaxPriceAmount *Money
+
+	// Offer describes the particular listing of this product that adding it to
+	// your cart from the wishlist would buy, or nil if it can't be added.
+	Offer *Offer
+
+	// OffersSummary describes the other new and used listings of this
+	// product, or is nil if Amazon doesn't mention any.
+	OffersSummary *OffersSummary
+
 	// ID is a unique identifier for this product on Amazon.
 	ID string
 
+	// ASIN is the Amazon Standard Identification Number of this product, which
+	// is the same on every wishlist it appears on, unlike ID.
+	ASIN string
+
+	// CanonicalASIN is the ASIN Amazon considers the main listing of this
+	// product, such as the parent of a particular size or color. It is the same
+	// as ASIN when Amazon doesn't specify a different one.
+	CanonicalASIN string
+
 	// DateAdded is a string representation of when this item was added to the
 	// wishlist. Example: "October 20, 2019"
 	RawDateAdded string
 
 	// Rating is a string description of how Amazon customers have rated this
-	// product.
+	// product. Example: "4.0 out of 5 stars"
 	Rating string
+
+	// Stars is how Amazon customers have rated this product, from 0 to 5 in
+	// steps of a half star, or 0 if it hasn't been rated.
+	Stars float64
+
+	// Position is the zero-based index of this product in the wishlist, in the
+	// order Amazon displays them across all pages of the wishlist.
+	Position int
+
+	marketplace *Marketplace
 }
 
 // NewItem constructs an Item with the given product identifier, name, and
@@ -68,13 +136,54 @@ func NewItem(id string, name string, directURL string) *Item {
 	}
 }
 
+// isPurchased reports whether as many of this product have been bought as the
+// wishlist recipient requested.
+func (i *Item) isPurchased() bool {
+	if i.OwnedCount < 1 {
+		return false
+	}
+	return i.RequestedCount < 0 || i.OwnedCount >= i.RequestedCount
+}
+
+// sortItems returns the given products as a list ordered by their position in
+// the wishlist.
+func sortItems(items map[string]*Item) []*Item {
+	list := make([]*Item, 0, len(items))
+	for _, item := range items {
+		list = append(list, item)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Position < list[j].Position
+	})
+
+	return list
+}
+
+// Attribute returns the value of the variation attribute with the given name,
+// ignoring case, e.g., "size". The second return value is false if this
+// product has no such attribute.
+func (i *Item) Attribute(name string) (string, bool) {
+	for _, attr := range i.Attributes {
+		if strings.EqualFold(attr.Name, name) {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
 // DateAdded returns the date this item was added to the wishlist.
 func (i *Item) DateAdded() (*time.Time, error) {
 	if i.RawDateAdded == "" {
 		return nil, fmt.Errorf("No date added found for item %s", i.ID)
 	}
 
-	date, err := time.Parse("January 2, 2006", i.RawDateAdded)
+	m := i.marketplace
+	if m == nil {
+		m = marketplaceFor(DefaultAmazonDomain)
+	}
+
+	date, err := m.ParseDate(i.RawDateAdded)
 	if err != nil {
 		return nil, err
 	}
@@ -101,6 +210,12 @@ func (i *Item) String() string {
 		sb.WriteString("\n")
 	}
 
+	if i.Byline != nil {
+		sb.WriteString("\t")
+		sb.WriteString(i.Byline.Raw)
+		sb.WriteString("\n")
+	}
+
 	line := strings.TrimSpace(strings.Join([]string{
 		i.Price,
 		i.Rating,
@@ -111,6 +226,17 @@ func (i *Item) String() string {
 		sb.WriteString("\n")
 	}
 
+	if i.OffersSummary != nil {
+		sb.WriteString("\t")
+		sb.WriteString(strconv.Itoa(i.OffersSummary.Count))
+		sb.WriteString(" used & new")
+		if i.OffersSummary.LowestPrice != nil {
+			sb.WriteString(" from ")
+			sb.WriteString(i.OffersSummary.LowestPrice.String())
+		}
+		sb.WriteString("\n")
+	}
+
 	if i.RawDateAdded != "" {
 		sb.WriteString("\tAdded ")
 		sb.WriteString(i.RawDateAdded)
@@ -121,6 +247,32 @@ func (i *Item) String() string {
 		sb.WriteString("\tPrime\n")
 	}
 
+	if i.Purchased {
+		sb.WriteString("\tPurchased\n")
+	}
+
+	if i.Priority != PriorityMedium {
+		sb.WriteString("\tPriority: ")
+		sb.WriteString(i.Priority.String())
+		sb.WriteString("\n")
+	}
+
+	if len(i.Attributes) > 0 {
+		attrs := make([]string, len(i.Attributes))
+		for index, attr := range i.Attributes {
+			attrs[index] = attr.Name + ": " + attr.Value
+		}
+		sb.WriteString("\t")
+		sb.WriteString(strings.Join(attrs, ", "))
+		sb.WriteString("\n")
+	}
+
+	if i.Comment != "" {
+		sb.WriteString("\tComment: ")
+		sb.WriteString(i.Comment)
+		sb.WriteString("\n")
+	}
+
 	if i.ReviewCount > 0 || i.ReviewsURL != "" {
 		sb.WriteString("\t")
 		if i.ReviewCount > 0 {
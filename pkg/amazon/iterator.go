@@ -0,0 +1,110 @@
+package amazon
+
+import (
+	"context"
+
+	"github.com/gocolly/colly"
+)
+
+// ItemIterator steps through the products on a wishlist as they are loaded,
+// rather than waiting for every page of the wishlist to load first. Amazon is
+// only asked for as many pages as are needed to keep up with Next, and no
+// more once the iterator is closed.
+//
+//	it := wishlist.Iterate()
+//	defer it.Close()
+//	for it.Next() {
+//		fmt.Println(it.Item())
+//	}
+//	if err := it.Err(); err != nil {
+//		log.Fatalln(err)
+//	}
+type ItemIterator struct {
+	items  chan *Item
+	cancel context.CancelFunc
+	item   *Item
+	err    error
+	closed bool
+}
+
+// Iterate returns an iterator over the products on the wishlist in the order
+// Amazon displays them, across all pages of the wishlist. The iterator must be
+// closed when no longer needed, unless Next has returned false.
+func (w *Wishlist) Iterate() *ItemIterator {
+	return w.IterateContext(context.Background())
+}
+
+// IterateContext returns an iterator over the products on the wishlist in the
+// order Amazon displays them, across all pages of the wishlist. Once the
+// context is done, no further pages are requested and the iterator stops with
+// the context's error. The iterator must be closed when no longer needed,
+// unless Next has returned false.
+func (w *Wishlist) IterateContext(ctx context.Context) *ItemIterator {
+	ctx, cancel := context.WithCancel(ctx)
+	it := &ItemIterator{
+		items:  make(chan *Item),
+		cancel: cancel,
+	}
+
+	s := w.scraper(ctx)
+	s.stream = it.items
+	c := s.collector()
+
+	c.OnHTML("ul li", s.onListItem)
+	c.OnHTML("a.wl-see-more", func(link *colly.HTMLElement) {
+		s.onLoadMoreLink(c, link)
+	})
+
+	go func() {
+		it.err = s.load(c)
+
+		w.mu.Lock()
+		w.urls = s.urls
+		w.errors = s.errors
+		w.warnings = s.warnings
+		w.cursor = s.cursor
+		w.mu.Unlock()
+
+		close(it.items)
+	}()
+
+	return it
+}
+
+// Next waits for the next product on the wishlist, which is then available
+// from Item. It returns false once there are no more products, or loading the
+// wishlist failed, or the iterator was closed.
+func (it *ItemIterator) Next() bool {
+	item, ok := <-it.items
+	if !ok {
+		it.item = nil
+		return false
+	}
+
+	it.item = item
+	return true
+}
+
+// Item returns the product found by the last call to Next.
+func (it *ItemIterator) Item() *Item {
+	return it.item
+}
+
+// Err returns the error, if any, that stopped the iterator before every
+// product was found. It should be checked once Next returns false.
+func (it *ItemIterator) Err() error {
+	if it.closed {
+		return nil
+	}
+	return it.err
+}
+
+// Close stops the iterator, so that no further pages of the wishlist are
+// requested, and waits for any request in progress to finish.
+func (it *ItemIterator) Close() {
+	it.closed = true
+	it.cancel()
+
+	for range it.items {
+	}
+}
@@ -0,0 +1,88 @@
+package amazon
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIterate(t *testing.T) {
+	id := "123abc"
+	itemIDs := []string{"I2G6UJO0FYWV8J", "I1BXZQ7XJ0DSLV", "I3K2PLM9XQ0AAB"}
+	ts := newPagedTestServer(t, id, singleItemPages(itemIDs...)...)
+	defer ts.Close()
+
+	wishlist, err := NewWishlistFromIDAtDomain(id, ts.URL)
+	require.NoError(t, err)
+	wishlist.CacheResults = false
+
+	it := wishlist.Iterate()
+	defer it.Close()
+
+	var found []string
+	for it.Next() {
+		require.Equal(t, len(found), it.Item().Position)
+		found = append(found, it.Item().ID)
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, itemIDs, found)
+	require.Nil(t, it.Item())
+	require.Len(t, wishlist.URLs(), len(itemIDs))
+}
+
+func TestIterateClose(t *testing.T) {
+	id := "123abc"
+	itemIDs := []string{"I2G6UJO0FYWV8J", "I1BXZQ7XJ0DSLV", "I3K2PLM9XQ0AAB", "I4ABCDEFGHIJK"}
+	ts := newPagedTestServer(t, id, singleItemPages(itemIDs...)...)
+	defer ts.Close()
+
+	wishlist, err := NewWishlistFromIDAtDomain(id, ts.URL)
+	require.NoError(t, err)
+	wishlist.CacheResults = false
+
+	it := wishlist.Iterate()
+	require.True(t, it.Next())
+	require.Equal(t, itemIDs[0], it.Item().ID)
+	it.Close()
+
+	require.False(t, it.Next())
+	require.NoError(t, it.Err())
+	requests := ts.requestCount()
+	require.True(t, requests <= 2, "should stop requesting pages once closed, but made %d requests", requests)
+}
+
+func TestIterateContextCanceled(t *testing.T) {
+	id := "123abc"
+	ts := newTestServer(t, id)
+	defer ts.Close()
+
+	wishlist, err := NewWishlistFromIDAtDomain(id, ts.URL)
+	require.NoError(t, err)
+	wishlist.CacheResults = false
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	it := wishlist.IterateContext(ctx)
+	defer it.Close()
+	require.False(t, it.Next())
+	require.Equal(t, context.Canceled, it.Err())
+}
+
+func TestIterateError(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	wishlist, err := NewWishlistFromIDAtDomain("123abc", ts.URL)
+	require.NoError(t, err)
+	wishlist.CacheResults = false
+
+	it := wishlist.Iterate()
+	defer it.Close()
+	require.False(t, it.Next())
+	require.True(t, errors.Is(it.Err(), ErrNotFound))
+}
@@ -0,0 +1,221 @@
+package amazon
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Marketplace describes one of Amazon's regional stores, and how wishlists on
+// it are written.
+type Marketplace struct {
+	// Domain is the store's domain without any "www." prefix, e.g.,
+	// "amazon.co.uk".
+	Domain string
+
+	// Currency is the ISO 4217 code of the currency prices are shown in.
+	Currency string
+
+	// Language is the BCP 47 tag of the language pages are written in, e.g.,
+	// "en-GB".
+	Language string
+
+	// DateLayout is the layout, as understood by time.Parse, of the dates
+	// products were added to a wishlist, e.g., "2 January 2006". Month names
+	// in the store's language are translated to English before parsing, so
+	// the layout is written with English month names.
+	DateLayout string
+
+	// DateAddedPrefix is the text shown before the date a product was added
+	// to a wishlist, e.g., "Added ".
+	DateAddedPrefix string
+
+	// DateAddedSuffix is the text shown after the date a product was added to
+	// a wishlist, e.g., "に追加".
+	DateAddedSuffix string
+
+	// RobotMessage is text Amazon shows when it thinks the visitor is a robot.
+	RobotMessage string
+
+	// AmazonMerchantID identifies Amazon itself as the seller of a product in
+	// this store, or is empty if not known.
+	AmazonMerchantID string
+}
+
+// marketplaces are the Amazon stores whose wishlists are known to parse.
+var marketplaces = []Marketplace{
+	{
+		Domain:           "amazon.com",
+		Currency:         "USD",
+		Language:         "en-US",
+		DateLayout:       "January 2, 2006",
+		DateAddedPrefix:  "Added ",
+		RobotMessage:     robotMessage,
+		AmazonMerchantID: "ATVPDKIKX0DER",
+	},
+	{
+		Domain:           "amazon.co.uk",
+		Currency:         "GBP",
+		Language:         "en-GB",
+		DateLayout:       "2 January 2006",
+		DateAddedPrefix:  "Added ",
+		RobotMessage:     robotMessage,
+		AmazonMerchantID: "A3P5ROKL5A1OLE",
+	},
+	{
+		Domain:           "amazon.de",
+		Currency:         "EUR",
+		Language:         "de-DE",
+		DateLayout:       "2. January 2006",
+		DateAddedPrefix:  "Hinzugefügt am ",
+		RobotMessage:     "dass Sie kein Roboter sind",
+		AmazonMerchantID: "A3JWKAKR8XB7XF",
+	},
+	{
+		Domain:           "amazon.fr",
+		Currency:         "EUR",
+		Language:         "fr-FR",
+		DateLayout:       "2 January 2006",
+		DateAddedPrefix:  "Ajouté le ",
+		RobotMessage:     "vous n'êtes pas un robot",
+		AmazonMerchantID: "A1X6FK5RDHNB96",
+	},
+	{
+		Domain:           "amazon.it",
+		Currency:         "EUR",
+		Language:         "it-IT",
+		DateLayout:       "2 January 2006",
+		DateAddedPrefix:  "Aggiunto il ",
+		RobotMessage:     "non sei un robot",
+		AmazonMerchantID: "A11IL2PNWYJU7H",
+	},
+	{
+		Domain:           "amazon.es",
+		Currency:         "EUR",
+		Language:         "es-ES",
+		DateLayout:       "2 de January de 2006",
+		DateAddedPrefix:  "Añadido el ",
+		RobotMessage:     "no eres un robot",
+		AmazonMerchantID: "A1AT7YVPFBWXBL",
+	},
+	{
+		Domain:           "amazon.ca",
+		Currency:         "CAD",
+		Language:         "en-CA",
+		DateLayout:       "January 2, 2006",
+		DateAddedPrefix:  "Added ",
+		RobotMessage:     robotMessage,
+		AmazonMerchantID: "A3DWYIK6Y9EEQB",
+	},
+	{
+		Domain:           "amazon.co.jp",
+		Currency:         "JPY",
+		Language:         "ja-JP",
+		DateLayout:       "2006年1月2日",
+		DateAddedSuffix:  "に追加",
+		RobotMessage:     "ロボットではないことを確認",
+		AmazonMerchantID: "AN1VRQENFRJN5",
+	},
+	{
+		Domain:           "amazon.com.au",
+		Currency:         "AUD",
+		Language:         "en-AU",
+		DateLayout:       "2 January 2006",
+		DateAddedPrefix:  "Added ",
+		RobotMessage:     robotMessage,
+		AmazonMerchantID: "ANEGB3WVEVKZB",
+	},
+	{
+		Domain:          "amazon.in",
+		Currency:        "INR",
+		Language:        "en-IN",
+		DateLayout:      "2 January 2006",
+		DateAddedPrefix: "Added ",
+		RobotMessage:    robotMessage,
+	},
+	{
+		Domain:          "amazon.com.mx",
+		Currency:        "MXN",
+		Language:        "es-MX",
+		DateLayout:      "2 de January de 2006",
+		DateAddedPrefix: "Agregado el ",
+		RobotMessage:    "no eres un robot",
+	},
+	{
+		Domain:          "amazon.com.br",
+		Currency:        "BRL",
+		Language:        "pt-BR",
+		DateLayout:      "2 de January de 2006",
+		DateAddedPrefix: "Adicionado em ",
+		RobotMessage:    "você não é um robô",
+	},
+	{
+		Domain:          "amazon.nl",
+		Currency:        "EUR",
+		Language:        "nl-NL",
+		DateLayout:      "2 January 2006",
+		DateAddedPrefix: "Toegevoegd op ",
+		RobotMessage:    "dat u geen robot bent",
+	},
+	{
+		Domain:          "amazon.se",
+		Currency:        "SEK",
+		Language:        "sv-SE",
+		DateLayout:      "2 January 2006",
+		DateAddedPrefix: "Tillagd ",
+		RobotMessage:    "att du inte är en robot",
+	},
+}
+
+// Marketplaces returns every Amazon store whose wishlists are known to parse.
+func Marketplaces() []Marketplace {
+	list := make([]Marketplace, len(marketplaces))
+	copy(list, marketplaces)
+	return list
+}
+
+// LookupMarketplace returns the Amazon store at the given host, such as
+// "www.amazon.de", or URL, such as "https://www.amazon.de/hz/wishlist/ls/123".
+// The second return value is false if the store is not known.
+func LookupMarketplace(hostOrURL string) (Marketplace, bool) {
+	host := hostOrURL
+	if uri, err := url.Parse(hostOrURL); err == nil && uri.Host != "" {
+		host = uri.Hostname()
+	}
+	host = strings.TrimPrefix(strings.ToLower(host), "www.")
+
+	for _, m := range marketplaces {
+		if host == m.Domain {
+			return m, true
+		}
+	}
+
+	return Marketplace{}, false
+}
+
+// marketplaceFor returns the Amazon store at the given host or URL, assuming
+// the store at DefaultAmazonDomain if it is not known.
+func marketplaceFor(hostOrURL string) *Marketplace {
+	m, ok := LookupMarketplace(hostOrURL)
+	if !ok {
+		m, _ = LookupMarketplace(DefaultAmazonDomain)
+	}
+	return &m
+}
+
+// isRobotCheck reports whether the given page text is Amazon asking whether
+// the visitor is a robot.
+func (m *Marketplace) isRobotCheck(text string) bool {
+	if strings.Contains(text, robotMessage) {
+		return true
+	}
+	return m.RobotMessage != "" && strings.Contains(text, m.RobotMessage)
+}
+
+// trimDateAdded removes the text around the date a product was added to a
+// wishlist.
+func (m *Marketplace) trimDateAdded(text string) string {
+	text = strings.TrimSpace(text)
+	text = strings.TrimPrefix(text, m.DateAddedPrefix)
+	text = strings.TrimSuffix(text, m.DateAddedSuffix)
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,92 @@
+package amazon
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gocolly/colly"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupMarketplace(t *testing.T) {
+	m, ok := LookupMarketplace("www.amazon.de")
+	require.True(t, ok)
+	require.Equal(t, "amazon.de", m.Domain)
+	require.Equal(t, "EUR", m.Currency)
+	require.Equal(t, "de-DE", m.Language)
+
+	m, ok = LookupMarketplace("https://www.amazon.co.jp/hz/wishlist/ls/123abc")
+	require.True(t, ok)
+	require.Equal(t, "JPY", m.Currency)
+
+	m, ok = LookupMarketplace("AMAZON.COM.BR")
+	require.True(t, ok)
+	require.Equal(t, "BRL", m.Currency)
+
+	_, ok = LookupMarketplace("www.example.com")
+	require.False(t, ok)
+
+	require.Len(t, Marketplaces(), 14)
+}
+
+func TestWishlistMarketplace(t *testing.T) {
+	wishlist, err := NewWishlist("https://www.amazon.co.uk/hz/wishlist/ls/123abc")
+	require.NoError(t, err)
+	require.Equal(t, "amazon.co.uk", wishlist.Marketplace().Domain)
+
+	wishlist, err = NewWishlistFromIDAtDomain("123abc", "http://127.0.0.1:8080")
+	require.NoError(t, err)
+	require.Equal(t, "amazon.com", wishlist.Marketplace().Domain)
+}
+
+func TestMarketplaceRequestHeaders(t *testing.T) {
+	s := newScraper(context.Background(), "123abc", "https://www.amazon.de/hz/wishlist/ls/123abc")
+	r := &colly.Request{Headers: &http.Header{}}
+
+	s.onRequest(r)
+	require.Equal(t, "i18n-prefs=EUR", r.Headers.Get("cookie"))
+	require.Equal(t, "de-DE", r.Headers.Get("Accept-Language"))
+}
+
+func TestParseWishlistPageJapan(t *testing.T) {
+	html := strings.Replace(wishlistHTML, "Added July 10, 2019", "2019年7月10日に追加", 1)
+	html = strings.Replace(html, "$15.96", "￥1,500", -1)
+
+	page, err := ParseWishlistPage(strings.NewReader(html), "https://www.amazon.co.jp/hz/wishlist/ls/3I6EQPZ8OB1DT")
+	require.NoError(t, err)
+
+	item := page.Items["I2G6UJO0FYWV8J"]
+	require.Equal(t, "2019年7月10日", item.RawDateAdded)
+	dateAdded, err := item.DateAdded()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2019, 7, 10, 0, 0, 0, 0, time.UTC), *dateAdded)
+	require.Equal(t, &Money{Amount: 1500, Currency: "JPY"}, item.PriceAmount)
+}
+
+func TestParseWishlistPageMexico(t *testing.T) {
+	html := strings.Replace(wishlistHTML, "$15.96", "$1,299.00", -1)
+
+	page, err := ParseWishlistPage(strings.NewReader(html), "https://www.amazon.com.mx/hz/wishlist/ls/3I6EQPZ8OB1DT")
+	require.NoError(t, err)
+
+	item := page.Items["I2G6UJO0FYWV8J"]
+	require.Equal(t, &Money{Amount: 129900, Currency: "MXN"}, item.PriceAmount)
+	require.Equal(t, &Money{Amount: 129900, Currency: "MXN"}, item.OffersSummary.LowestPrice)
+}
+
+func TestParseWishlistPageUnitedKingdom(t *testing.T) {
+	html := strings.Replace(wishlistHTML, "Added July 10, 2019", "Added 10 July 2019", 1)
+	html = strings.Replace(html, "$15.96", "£12.50", -1)
+
+	page, err := ParseWishlistPage(strings.NewReader(html), "https://www.amazon.co.uk/hz/wishlist/ls/3I6EQPZ8OB1DT")
+	require.NoError(t, err)
+
+	item := page.Items["I2G6UJO0FYWV8J"]
+	dateAdded, err := item.DateAdded()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2019, 7, 10, 0, 0, 0, 0, time.UTC), *dateAdded)
+	require.Equal(t, &Money{Amount: 1250, Currency: "GBP"}, item.PriceAmount)
+}
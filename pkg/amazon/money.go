@@ -0,0 +1,237 @@
+package amazon
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Money is an amount of money in a particular currency.
+type Money struct {
+	// Amount is the value in the currency's minor units, e.g., cents for USD
+	// or yen for JPY.
+	Amount int64 `json:"amount"`
+
+	// Currency is the ISO 4217 code of the currency, e.g., "USD".
+	Currency string `json:"currency"`
+}
+
+// currencySymbols maps the ways Amazon writes currencies in prices to their
+// ISO 4217 codes. Longer symbols must be matched before their suffixes, e.g.,
+// "CDN$" before "$".
+var currencySymbols = []struct {
+	symbol   string
+	currency string
+}{
+	{"CDN$", "CAD"},
+	{"CA$", "CAD"},
+	{"C$", "CAD"},
+	{"AU$", "AUD"},
+	{"A$", "AUD"},
+	{"US$", "USD"},
+	{"MX$", "MXN"},
+	{"R$", "BRL"},
+	{"Rs.", "INR"},
+	{"EUR", "EUR"},
+	{"GBP", "GBP"},
+	{"USD", "USD"},
+	{"CAD", "CAD"},
+	{"AUD", "AUD"},
+	{"JPY", "JPY"},
+	{"INR", "INR"},
+	{"MXN", "MXN"},
+	{"BRL", "BRL"},
+	{"SEK", "SEK"},
+	{"kr", "SEK"},
+	{"£", "GBP"},
+	{"€", "EUR"},
+	{"¥", "JPY"},
+	{"￥", "JPY"},
+	{"₹", "INR"},
+	{"$", "USD"},
+}
+
+// sharedSymbols lists the currency symbols that more than one of Amazon's
+// stores uses, and the currencies they may stand for. The currency given for
+// such a symbol in currencySymbols is only assumed when a price's default
+// currency is not one of these.
+var sharedSymbols = map[string][]string{
+	"$": {"USD", "CAD", "AUD", "MXN"},
+	"¥": {"JPY", "CNY"},
+	"￥": {"JPY", "CNY"},
+}
+
+// minorUnitDigits lists currencies that don't have two digits of minor units.
+var minorUnitDigits = map[string]int{
+	"JPY": 0,
+}
+
+// priceRangeSeparators are the ways Amazon separates the low and high ends of
+// a range of prices.
+var priceRangeSeparators = []string{" - ", " – ", " — ", "-", "–", "—"}
+
+// ParseMoney parses a price as Amazon displays it, such as "$15.96",
+// "CDN$ 1,234.50", "12,99 €" or "￥1,500", into an amount of money. When the
+// price names no currency, or only a symbol shared by several currencies such
+// as "$" that the default currency uses, the given default currency is
+// assumed.
+func ParseMoney(price string, defaultCurrency string) (*Money, error) {
+	raw := price
+	price = strings.TrimSpace(price)
+
+	currency := ""
+	for _, cs := range currencySymbols {
+		if strings.HasPrefix(price, cs.symbol) {
+			currency = symbolCurrency(cs.symbol, cs.currency, defaultCurrency)
+			price = strings.TrimPrefix(price, cs.symbol)
+			break
+		}
+		if strings.HasSuffix(price, cs.symbol) {
+			currency = symbolCurrency(cs.symbol, cs.currency, defaultCurrency)
+			price = strings.TrimSuffix(price, cs.symbol)
+			break
+		}
+	}
+	if currency == "" {
+		currency = defaultCurrency
+	}
+	if currency == "" {
+		return nil, fmt.Errorf("No currency found in price '%s'", raw)
+	}
+
+	amount, err := parseAmount(strings.TrimFunc(price, unicode.IsSpace), currencyDigits(currency))
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse price '%s': %s", raw, err)
+	}
+
+	return &Money{Amount: amount, Currency: currency}, nil
+}
+
+// symbolCurrency returns the currency the given symbol stands for in a price
+// whose default currency is given, which for a symbol shared by several
+// currencies is the default currency if it is one of them.
+func symbolCurrency(symbol string, currency string, defaultCurrency string) string {
+	for _, shared := range sharedSymbols[symbol] {
+		if shared == defaultCurrency {
+			return defaultCurrency
+		}
+	}
+	return currency
+}
+
+// ParsePriceRange parses a price as Amazon displays it, which may be a range
+// such as "$10.99 - $24.99", into its low and high amounts. For a single
+// price, both amounts are the same.
+func ParsePriceRange(price string, defaultCurrency string) (*Money, *Money, error) {
+	for _, separator := range priceRangeSeparators {
+		parts := strings.SplitN(price, separator, 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			continue
+		}
+
+		low, err := ParseMoney(parts[0], defaultCurrency)
+		if err != nil {
+			continue
+		}
+		high, err := ParseMoney(parts[1], low.Currency)
+		if err != nil {
+			continue
+		}
+
+		return low, high, nil
+	}
+
+	money, err := ParseMoney(price, defaultCurrency)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return money, money, nil
+}
+
+// String returns the amount of money with its currency code, e.g.,
+// "15.96 USD".
+func (m *Money) String() string {
+	digits := currencyDigits(m.Currency)
+	if digits < 1 {
+		return fmt.Sprintf("%d %s", m.Amount, m.Currency)
+	}
+
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	scale := pow10(digits)
+	return fmt.Sprintf("%s%d.%0*d %s", sign, amount/scale, digits, amount%scale,
+		m.Currency)
+}
+
+func currencyDigits(currency string) int {
+	if digits, ok := minorUnitDigits[currency]; ok {
+		return digits
+	}
+	return 2
+}
+
+func pow10(digits int) int64 {
+	scale := int64(1)
+	for i := 0; i < digits; i++ {
+		scale *= 10
+	}
+	return scale
+}
+
+// parseAmount converts a number such as "1,234.56", "1.234,56", "1 234,56" or
+// "1,23,456" into minor units. The last separator is taken to be the decimal
+// separator if no more digits than the currency's minor units follow it;
+// every other separator groups thousands.
+func parseAmount(number string, digits int) (int64, error) {
+	if number == "" {
+		return 0, fmt.Errorf("no amount found")
+	}
+
+	var groups []string
+	current := strings.Builder{}
+	lastSeparator := rune(0)
+	for _, r := range number {
+		switch {
+		case r >= '0' && r <= '9':
+			current.WriteRune(r)
+		case r == ',' || r == '.' || r == '\'' || unicode.IsSpace(r):
+			if current.Len() < 1 {
+				return 0, fmt.Errorf("unexpected '%c'", r)
+			}
+			groups = append(groups, current.String())
+			current.Reset()
+			lastSeparator = r
+		default:
+			return 0, fmt.Errorf("unexpected '%c'", r)
+		}
+	}
+	if current.Len() < 1 {
+		return 0, fmt.Errorf("amount ends with '%c'", lastSeparator)
+	}
+	groups = append(groups, current.String())
+
+	whole := strings.Join(groups, "")
+	fraction := ""
+	if last := groups[len(groups)-1]; len(groups) > 1 && digits > 0 &&
+		len(last) <= digits && (lastSeparator == ',' || lastSeparator == '.') {
+		whole = strings.Join(groups[:len(groups)-1], "")
+		fraction = last
+	}
+	for len(fraction) < digits {
+		fraction += "0"
+	}
+
+	amount, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return amount, nil
+}
@@ -0,0 +1,91 @@
+package amazon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		price    string
+		amount   int64
+		currency string
+	}{
+		{"$15.96", 1596, "USD"},
+		{"$1,234.56", 123456, "USD"},
+		{"$1,234", 123400, "USD"},
+		{"£7.99", 799, "GBP"},
+		{"12,99 €", 1299, "EUR"},
+		{"EUR 1.234,56", 123456, "EUR"},
+		{"1 234,56 €", 123456, "EUR"},
+		{"¥1,500", 1500, "JPY"},
+		{"￥ 12.800", 12800, "JPY"},
+		{"CDN$ 24.99", 2499, "CAD"},
+		{"₹1,23,456.00", 12345600, "INR"},
+		{"R$ 59,90", 5990, "BRL"},
+		{"129,00 kr", 12900, "SEK"},
+		{"19.5", 1950, "USD"},
+	}
+
+	for _, test := range tests {
+		money, err := ParseMoney(test.price, "USD")
+		require.NoError(t, err, test.price)
+		require.Equal(t, test.amount, money.Amount, test.price)
+		require.Equal(t, test.currency, money.Currency, test.price)
+	}
+}
+
+func TestParseMoneySharedSymbol(t *testing.T) {
+	tests := []struct {
+		price           string
+		defaultCurrency string
+		amount          int64
+		currency        string
+	}{
+		{"$1,299.00", "MXN", 129900, "MXN"},
+		{"$29.95", "AUD", 2995, "AUD"},
+		{"$24.99", "CAD", 2499, "CAD"},
+		{"$15.96", "", 1596, "USD"},
+		{"$15.96", "EUR", 1596, "USD"},
+		{"CDN$ 24.99", "USD", 2499, "CAD"},
+		{"¥1,500", "", 1500, "JPY"},
+		{"¥1,500", "JPY", 1500, "JPY"},
+	}
+
+	for _, test := range tests {
+		money, err := ParseMoney(test.price, test.defaultCurrency)
+		require.NoError(t, err, test.price)
+		require.Equal(t, &Money{Amount: test.amount, Currency: test.currency}, money,
+			"%s with default %s", test.price, test.defaultCurrency)
+	}
+}
+
+func TestParseMoneyInvalid(t *testing.T) {
+	for _, price := range []string{"", "$", "-Infinity", "free", "$1,,000"} {
+		_, err := ParseMoney(price, "USD")
+		require.Error(t, err, price)
+	}
+}
+
+func TestParsePriceRange(t *testing.T) {
+	low, high, err := ParsePriceRange("$10.99 - $24.99", "USD")
+	require.NoError(t, err)
+	require.Equal(t, &Money{Amount: 1099, Currency: "USD"}, low)
+	require.Equal(t, &Money{Amount: 2499, Currency: "USD"}, high)
+
+	low, high, err = ParsePriceRange("10,99 € – 24,99 €", "USD")
+	require.NoError(t, err)
+	require.Equal(t, &Money{Amount: 1099, Currency: "EUR"}, low)
+	require.Equal(t, &Money{Amount: 2499, Currency: "EUR"}, high)
+
+	low, high, err = ParsePriceRange("£5.00", "USD")
+	require.NoError(t, err)
+	require.Equal(t, low, high)
+}
+
+func TestMoneyString(t *testing.T) {
+	require.Equal(t, "15.96 USD", (&Money{Amount: 1596, Currency: "USD"}).String())
+	require.Equal(t, "0.05 EUR", (&Money{Amount: 5, Currency: "EUR"}).String())
+	require.Equal(t, "1500 JPY", (&Money{Amount: 1500, Currency: "JPY"}).String())
+}
@@ -0,0 +1,86 @@
+package amazon
+
+import (
+	"strconv"
+)
+
+// Offer describes a particular listing of a product on Amazon, such as the one
+// adding it to your cart from a wishlist would buy.
+type Offer struct {
+	// MerchantID identifies the seller of this listing.
+	MerchantID string `json:"merchantId,omitempty"`
+
+	// SoldByAmazon indicates whether Amazon itself is the seller, rather than
+	// a third-party merchant.
+	SoldByAmazon bool `json:"soldByAmazon"`
+
+	// OfferID identifies this listing on Amazon.
+	OfferID string `json:"offerId,omitempty"`
+
+	// Price is the cost of the product from this listing, or nil if not known.
+	Price *Money `json:"price,omitempty"`
+
+	// ProductGroup is Amazon's category for the product, e.g.,
+	// "gl_pet_products".
+	ProductGroup string `json:"productGroup,omitempty"`
+
+	// Quantity is how many of the product would be added to your cart.
+	Quantity int `json:"quantity"`
+
+	// IsGift indicates whether the product would be bought as a gift for the
+	// wishlist's owner.
+	IsGift bool `json:"isGift"`
+
+	// PromotionID identifies a promotion that applies to this listing, or is
+	// empty if there is none.
+	PromotionID string `json:"promotionId,omitempty"`
+}
+
+// OffersSummary describes the other listings of a product, new and used, as
+// Amazon summarizes them on a wishlist, e.g., "6 Used & New from $15.96".
+type OffersSummary struct {
+	// Count is how many listings there are.
+	Count int `json:"count"`
+
+	// LowestPrice is the cheapest of the listings, or nil if not known.
+	LowestPrice *Money `json:"lowestPrice,omitempty"`
+
+	// URL is the absolute URL of the page listing every offer.
+	URL string `json:"url,omitempty"`
+}
+
+// addToCartData is the JSON Amazon attaches to a product's add to cart button.
+type addToCartData struct {
+	ASIN           string `json:"asin"`
+	CanonicalASIN  string `json:"canonicalAsin"`
+	MerchantID     string `json:"merchantID"`
+	OfferID        string `json:"offerID"`
+	Price          string `json:"price"`
+	ProductGroupID string `json:"productGroupID"`
+	Quantity       string `json:"quantity"`
+	IsGift         string `json:"isGift"`
+	PromotionID    string `json:"promotionID"`
+}
+
+// offer returns the listing described by this add to cart button in the given
+// Amazon store.
+func (d *addToCartData) offer(m *Marketplace) *Offer {
+	offer := &Offer{
+		MerchantID:   d.MerchantID,
+		SoldByAmazon: m.AmazonMerchantID != "" && d.MerchantID == m.AmazonMerchantID,
+		OfferID:      d.OfferID,
+		ProductGroup: d.ProductGroupID,
+		IsGift:       d.IsGift == "1" || d.IsGift == "true",
+		PromotionID:  d.PromotionID,
+	}
+
+	if price, err := ParseMoney(d.Price, m.Currency); err == nil {
+		offer.Price = price
+	}
+
+	if quantity, err := strconv.Atoi(d.Quantity); err == nil {
+		offer.Quantity = quantity
+	}
+
+	return offer
+}
@@ -0,0 +1,81 @@
+package amazon
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWishlistPageOffer(t *testing.T) {
+	page, err := ParseWishlistPage(strings.NewReader(wishlistHTML), "https://www.amazon.com/hz/wishlist/ls/3I6EQPZ8OB1DT")
+	require.NoError(t, err)
+
+	offer := page.Items["I2G6UJO0FYWV8J"].Offer
+	require.NotNil(t, offer)
+	require.Equal(t, "ATVPDKIKX0DER", offer.MerchantID)
+	require.True(t, offer.SoldByAmazon, "should be sold by Amazon")
+	require.Equal(t, "N0lddTThI8GWEpI7QRL4cNNuzpcBzmBFRWnl3mKyf0U9O8OhdQCZfP6fLzAET35hPHczdSksADU5WY4Neiw9Bi6%2BCQEVDh5EfUzvS%2FRAbtA2hZcoDu3kCQ%3D%3D", offer.OfferID)
+	require.Equal(t, &Money{Amount: 1596, Currency: "USD"}, offer.Price)
+	require.Equal(t, "gl_pet_products", offer.ProductGroup)
+	require.Equal(t, 1, offer.Quantity)
+	require.True(t, offer.IsGift, "should be bought as a gift")
+	require.Equal(t, "", offer.PromotionID)
+}
+
+func TestParseWishlistPageThirdPartyOffer(t *testing.T) {
+	html := strings.Replace(wishlistHTML, "&quot;merchantID&quot;:&quot;ATVPDKIKX0DER&quot;",
+		"&quot;merchantID&quot;:&quot;A2R2RITDJNW1Q6&quot;", 1)
+
+	page, err := ParseWishlistPage(strings.NewReader(html), "https://www.amazon.com/hz/wishlist/ls/3I6EQPZ8OB1DT")
+	require.NoError(t, err)
+
+	offer := page.Items["I2G6UJO0FYWV8J"].Offer
+	require.NotNil(t, offer)
+	require.Equal(t, "A2R2RITDJNW1Q6", offer.MerchantID)
+	require.False(t, offer.SoldByAmazon, "should be sold by a third party")
+}
+
+func TestParseWishlistPageNoOffer(t *testing.T) {
+	html := strings.Replace(wishlistHTML, `data-action="add-to-cart"`, `data-action="none"`, 1)
+
+	page, err := ParseWishlistPage(strings.NewReader(html), "https://www.amazon.com/hz/wishlist/ls/3I6EQPZ8OB1DT")
+	require.NoError(t, err)
+	require.Nil(t, page.Items["I2G6UJO0FYWV8J"].Offer)
+}
+
+func TestParseWishlistPageOffersSummary(t *testing.T) {
+	page, err := ParseWishlistPage(strings.NewReader(wishlistHTML), "https://www.amazon.com/hz/wishlist/ls/3I6EQPZ8OB1DT")
+	require.NoError(t, err)
+
+	item := page.Items["I2G6UJO0FYWV8J"]
+	require.Equal(t, &OffersSummary{
+		Count:       6,
+		LowestPrice: &Money{Amount: 1596, Currency: "USD"},
+		URL:         "https://www.amazon.com/gp/offer-listing/B0018CLTKE/?colid=3I6EQPZ8OB1DT&coliid=I2G6UJO0FYWV8J&ref_=lv_vv_lig_uan_ol",
+	}, item.OffersSummary)
+	require.Contains(t, item.String(), "\t6 used & new from 15.96 USD\n")
+}
+
+func TestParseWishlistPageOffersSummaryGermany(t *testing.T) {
+	html := strings.Replace(wishlistHTML, "6 Used &amp; New", "1.234 neu und gebraucht", 1)
+	html = strings.Replace(html, `<span class="a-color-price itemUsedAndNewPrice">$15.96</span>`,
+		`<span class="a-color-price itemUsedAndNewPrice">12,99 €</span>`, 1)
+
+	page, err := ParseWishlistPage(strings.NewReader(html), "https://www.amazon.de/hz/wishlist/ls/3I6EQPZ8OB1DT")
+	require.NoError(t, err)
+
+	summary := page.Items["I2G6UJO0FYWV8J"].OffersSummary
+	require.NotNil(t, summary)
+	require.Equal(t, 1234, summary.Count)
+	require.Equal(t, &Money{Amount: 1299, Currency: "EUR"}, summary.LowestPrice)
+	require.Equal(t, "https://www.amazon.de/gp/offer-listing/B0018CLTKE/?colid=3I6EQPZ8OB1DT&coliid=I2G6UJO0FYWV8J&ref_=lv_vv_lig_uan_ol", summary.URL)
+}
+
+func TestParseWishlistPageNoOffersSummary(t *testing.T) {
+	html := strings.Replace(wishlistHTML, `class="a-row itemUsedAndNew"`, `class="a-row"`, 1)
+
+	page, err := ParseWishlistPage(strings.NewReader(html), "https://www.amazon.com/hz/wishlist/ls/3I6EQPZ8OB1DT")
+	require.NoError(t, err)
+	require.Nil(t, page.Items["I2G6UJO0FYWV8J"].OffersSummary)
+}
@@ -0,0 +1,113 @@
+package amazon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly"
+)
+
+// Page represents a single page of an Amazon wishlist, as parsed from its
+// HTML source.
+type Page struct {
+	// URL is the address of this page, used to resolve relative links in it.
+	URL string
+
+	// Name is the name of the wishlist.
+	Name string
+
+	// PrintURL is the URL to the printer-friendly view of the wishlist.
+	PrintURL string
+
+	// NextPageURL is the URL to the next page of the wishlist, or an empty
+	// string if this is the last page.
+	NextPageURL string
+
+	// Items is a map of the products on this page, where keys are the product
+	// IDs and the values are the products.
+	Items map[string]*Item
+
+	// Warnings are details of products on this page that could not be parsed,
+	// but did not prevent the page from being parsed.
+	Warnings []*FieldError
+}
+
+// ItemList returns the products on this page in the order they appear.
+func (p *Page) ItemList() []*Item {
+	return sortItems(p.Items)
+}
+
+// ParseWishlistPage extracts the products and details of a wishlist from the
+// HTML source of one of its pages, such as those saved in DebugMode. No
+// requests are made to Amazon. The given base URL is the address the page was
+// loaded from and is used to resolve relative links.
+//
+// If details of some products could not be parsed, the page is returned with
+// a *MultiError holding every such error, as with PartialResults. Any other
+// error means the page could not be parsed at all, and no page is returned.
+func ParseWishlistPage(r io.Reader, baseURL string) (*Page, error) {
+	uri, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if !uri.IsAbs() {
+		return nil, fmt.Errorf("URL '%s' is not an absolute URL to an Amazon wishlist",
+			baseURL)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if href, found := doc.Find("base[href]").Attr("href"); found {
+		if base, err := uri.Parse(href); err == nil {
+			uri = base
+		}
+	}
+
+	s := newScraper(context.Background(), "", baseURL)
+
+	if err := pageError(s.marketplace, uri, 0, doc.Selection); err != nil {
+		return nil, err
+	}
+
+	page := &Page{URL: baseURL}
+	resp := &colly.Response{Request: &colly.Request{URL: uri, Ctx: colly.NewContext()}}
+
+	forEachHTML(doc, resp, "#profile-list-name", s.onName)
+	forEachHTML(doc, resp, "#wl-print-link", s.onPrintLink)
+	forEachHTML(doc, resp, "ul li", s.onListItem)
+	forEachHTML(doc, resp, "a.wl-see-more", func(link *colly.HTMLElement) {
+		relativeURL := link.Attr("href")
+		if len(relativeURL) < 1 {
+			return
+		}
+
+		page.NextPageURL = link.Request.AbsoluteURL(relativeURL)
+	})
+
+	page.Name = s.name
+	page.PrintURL = s.printURL
+	page.Items = s.items
+	page.Warnings = s.warnings
+
+	if len(s.errors) > 0 {
+		return page, &MultiError{Errors: s.errors}
+	}
+	return page, nil
+}
+
+// forEachHTML calls the given callback for each element in the document that
+// matches the selector, the same way a colly collector does for OnHTML.
+func forEachHTML(doc *goquery.Document, resp *colly.Response, selector string, f colly.HTMLCallback) {
+	doc.Find(selector).Each(func(i int, s *goquery.Selection) {
+		for _, n := range s.Nodes {
+			f(colly.NewHTMLElementFromSelectionNode(resp, s, n, i))
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package amazon
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWishlistPage(t *testing.T) {
+	baseURL := "https://www.amazon.com/hz/wishlist/ls/3I6EQPZ8OB1DT"
+
+	page, err := ParseWishlistPage(strings.NewReader(wishlistHTML), baseURL)
+	require.NoError(t, err)
+	require.Equal(t, baseURL, page.URL)
+	require.Equal(t, "NHA Wish List", page.Name)
+	require.Equal(t, "https://www.amazon.com/hz/wishlist/printview/3I6EQPZ8OB1DT", page.PrintURL)
+	require.Equal(t, "", page.NextPageURL)
+	require.Len(t, page.Items, 1)
+
+	itemID := "I2G6UJO0FYWV8J"
+	item, ok := page.Items[itemID]
+	require.True(t, ok)
+	require.Equal(t, "Purina Tidy Cats Non-Clumping Cat Litter", item.Name)
+	require.Equal(t, "$15.96", item.Price)
+	require.Equal(t, "July 10, 2019", item.RawDateAdded)
+	require.Equal(t, 50, item.RequestedCount)
+	require.Equal(t, 11, item.OwnedCount)
+	require.Equal(t, 930, item.ReviewCount)
+	require.True(t, item.IsPrime, "should be marked as a Prime item")
+	require.Equal(t, "https://www.amazon.com/dp/B0018CLTKE/?coliid=I2G6UJO0FYWV8J&colid=3I6EQPZ8OB1DT&psc=1&ref_=lv_vv_lig_dp_it", item.DirectURL)
+}
+
+func TestParseWishlistPageNextPage(t *testing.T) {
+	html := wishlistPageHTML([]string{"I2G6UJO0FYWV8J"}, "/hz/wishlist/ls/3I6EQPZ8OB1DT?lek=abc123")
+
+	page, err := ParseWishlistPage(strings.NewReader(html), "https://www.amazon.co.uk/hz/wishlist/ls/3I6EQPZ8OB1DT")
+	require.NoError(t, err)
+	require.Equal(t, "https://www.amazon.co.uk/hz/wishlist/ls/3I6EQPZ8OB1DT?lek=abc123", page.NextPageURL)
+}
+
+func TestParseWishlistPageDataPrice(t *testing.T) {
+	html := strings.Replace(wishlistHTML, `<span class="a-offscreen">$15.96</span>`, `<span class="a-offscreen"></span>`, 1)
+	html = strings.Replace(html, `<span class="a-color-price itemUsedAndNewPrice">$15.96</span>`, "", 1)
+	html = strings.Replace(html, `data-price="15.96"`, `data-price="17.5"`, 1)
+
+	page, err := ParseWishlistPage(strings.NewReader(html), "https://www.amazon.com/hz/wishlist/ls/3I6EQPZ8OB1DT")
+	require.NoError(t, err)
+
+	item := page.Items["I2G6UJO0FYWV8J"]
+	require.Equal(t, "", item.Price)
+	require.Equal(t, &Money{Amount: 1750, Currency: "USD"}, item.PriceAmount)
+}
+
+func TestParseWishlistPageASIN(t *testing.T) {
+	baseURL := "https://www.amazon.com/hz/wishlist/ls/3I6EQPZ8OB1DT"
+
+	page, err := ParseWishlistPage(strings.NewReader(wishlistHTML), baseURL)
+	require.NoError(t, err)
+	item := page.Items["I2G6UJO0FYWV8J"]
+	require.Equal(t, "B0018CLTKE", item.ASIN)
+	require.Equal(t, "B07V2PT83J", item.CanonicalASIN)
+
+	html := strings.Replace(wishlistHTML, `data-add-to-cart=`, `data-removed=`, 1)
+	page, err = ParseWishlistPage(strings.NewReader(html), baseURL)
+	require.NoError(t, err)
+	item = page.Items["I2G6UJO0FYWV8J"]
+	require.Equal(t, "B0018CLTKE", item.ASIN, "should fall back to the list item's JSON")
+	require.Equal(t, "B0018CLTKE", item.CanonicalASIN)
+
+	html = strings.Replace(html, `data-reposition-action-params=`, `data-removed=`, 1)
+	page, err = ParseWishlistPage(strings.NewReader(html), baseURL)
+	require.NoError(t, err)
+	item = page.Items["I2G6UJO0FYWV8J"]
+	require.Equal(t, "B0018CLTKE", item.ASIN, "should fall back to the product URL")
+	require.Equal(t, "B0018CLTKE", item.CanonicalASIN)
+}
+
+func TestParseWishlistPageAttributes(t *testing.T) {
+	page, err := ParseWishlistPage(strings.NewReader(wishlistHTML), "https://www.amazon.com/hz/wishlist/ls/3I6EQPZ8OB1DT")
+	require.NoError(t, err)
+
+	item := page.Items["I2G6UJO0FYWV8J"]
+	require.Equal(t, []Attribute{
+		{Name: "Size", Value: "Instant Action"},
+		{Name: "Style", Value: "(4) 10 lb. Bags"},
+	}, item.Attributes)
+
+	style, ok := item.Attribute("style")
+	require.True(t, ok)
+	require.Equal(t, "(4) 10 lb. Bags", style)
+	_, ok = item.Attribute("Color")
+	require.False(t, ok)
+
+	require.Contains(t, item.String(), "\tSize: Instant Action, Style: (4) 10 lb. Bags\n")
+}
+
+func TestParseWishlistPageJapaneseAttributes(t *testing.T) {
+	html := strings.Replace(wishlistHTML, "Size : Instant Action", "サイズ：M", 1)
+
+	page, err := ParseWishlistPage(strings.NewReader(html), "https://www.amazon.co.jp/hz/wishlist/ls/3I6EQPZ8OB1DT")
+	require.NoError(t, err)
+
+	size, ok := page.Items["I2G6UJO0FYWV8J"].Attribute("サイズ")
+	require.True(t, ok)
+	require.Equal(t, "M", size)
+}
+
+func TestParseWishlistPageFieldError(t *testing.T) {
+	html := strings.Replace(wishlistHTML, ">50</span>", ">fifty</span>", 1)
+
+	page, err := ParseWishlistPage(strings.NewReader(html), "https://www.amazon.com/hz/wishlist/ls/3I6EQPZ8OB1DT")
+	require.Error(t, err)
+	require.NotNil(t, page)
+	require.Equal(t, "NHA Wish List", page.Name)
+
+	item := page.Items["I2G6UJO0FYWV8J"]
+	require.NotNil(t, item)
+	require.Equal(t, -1, item.RequestedCount)
+	require.Equal(t, 11, item.OwnedCount)
+
+	var multiErr *MultiError
+	require.True(t, errors.As(err, &multiErr))
+	require.Len(t, multiErr.Errors, 1)
+
+	var fieldErr *FieldError
+	require.True(t, errors.As(err, &fieldErr))
+	require.Equal(t, "RequestedCount", fieldErr.Field)
+}
+
+func TestParseWishlistPageRelativeURL(t *testing.T) {
+	_, err := ParseWishlistPage(strings.NewReader(wishlistHTML), "/hz/wishlist/ls/3I6EQPZ8OB1DT")
+	require.Error(t, err)
+}
@@ -0,0 +1,57 @@
+package amazon
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Priority is how much the recipient of a wishlist wants a product on it. Its
+// value is the number Amazon uses for it.
+type Priority int
+
+const (
+	// PriorityLowest is the lowest priority a product can have.
+	PriorityLowest Priority = -2
+
+	// PriorityLow is a lower than usual priority.
+	PriorityLow Priority = -1
+
+	// PriorityMedium is the priority products have unless the wishlist's
+	// owner chooses otherwise.
+	PriorityMedium Priority = 0
+
+	// PriorityHigh is a higher than usual priority.
+	PriorityHigh Priority = 1
+
+	// PriorityHighest is the highest priority a product can have.
+	PriorityHighest Priority = 2
+)
+
+var priorityNames = map[Priority]string{
+	PriorityLowest:  "lowest",
+	PriorityLow:     "low",
+	PriorityMedium:  "medium",
+	PriorityHigh:    "high",
+	PriorityHighest: "highest",
+}
+
+// ParsePriority returns the priority with the given name, as Amazon labels
+// priorities on amazon.com, e.g., "highest". The second return value is false
+// if the name is not known.
+func ParsePriority(name string) (Priority, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for priority, priorityName := range priorityNames {
+		if name == priorityName {
+			return priority, true
+		}
+	}
+	return PriorityMedium, false
+}
+
+// String returns the name of this priority, e.g., "highest".
+func (p Priority) String() string {
+	if name, ok := priorityNames[p]; ok {
+		return name
+	}
+	return strconv.Itoa(int(p))
+}
@@ -0,0 +1,54 @@
+package amazon
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePriority(t *testing.T) {
+	priority, ok := ParsePriority("Highest")
+	require.True(t, ok)
+	require.Equal(t, PriorityHighest, priority)
+
+	priority, ok = ParsePriority("low")
+	require.True(t, ok)
+	require.Equal(t, PriorityLow, priority)
+
+	_, ok = ParsePriority("urgent")
+	require.False(t, ok)
+}
+
+func TestPriorityString(t *testing.T) {
+	require.Equal(t, "lowest", PriorityLowest.String())
+	require.Equal(t, "medium", PriorityMedium.String())
+	require.Equal(t, "5", Priority(5).String())
+}
+
+func TestParseWishlistPagePriorityAndComment(t *testing.T) {
+	html := strings.Replace(wishlistHTML, "item-priority-medium\">medium", "item-priority-highest\">highest", 1)
+	html = strings.Replace(html, `<span id="itemPriority_I2G6UJO0FYWV8J" class="a-hidden">0</span>`,
+		`<span id="itemPriority_I2G6UJO0FYWV8J" class="a-hidden">2</span>`, 1)
+	html = strings.Replace(html, `class="g-comment-quote a-text-quote"></span>`,
+		`class="g-comment-quote a-text-quote"> size M please </span>`, 1)
+
+	page, err := ParseWishlistPage(strings.NewReader(html), "https://www.amazon.com/hz/wishlist/ls/3I6EQPZ8OB1DT")
+	require.NoError(t, err)
+
+	item := page.Items["I2G6UJO0FYWV8J"]
+	require.Equal(t, PriorityHighest, item.Priority)
+	require.Equal(t, "size M please", item.Comment)
+	require.Contains(t, item.String(), "\tPriority: highest\n")
+	require.Contains(t, item.String(), "\tComment: size M please\n")
+}
+
+func TestParseWishlistPageDefaultPriority(t *testing.T) {
+	page, err := ParseWishlistPage(strings.NewReader(wishlistHTML), "https://www.amazon.com/hz/wishlist/ls/3I6EQPZ8OB1DT")
+	require.NoError(t, err)
+
+	item := page.Items["I2G6UJO0FYWV8J"]
+	require.Equal(t, PriorityMedium, item.Priority)
+	require.Equal(t, "", item.Comment)
+	require.NotContains(t, item.String(), "Priority")
+}
@@ -0,0 +1,86 @@
+package amazon
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxStars is the best rating Amazon customers can give a product.
+const maxStars = 5
+
+// starClassPattern matches the class Amazon gives a product's star rating
+// icon, e.g., "a-star-small-4" for 4 stars or "a-star-small-4-5" for 4.5.
+var starClassPattern = regexp.MustCompile(`^a-star(?:-mini|-small|-medium|-large)?-(\d)(?:-(5))?$`)
+
+// ratingNumberPattern matches a number in a rating description, e.g., "4.5" in
+// "4.5 out of 5 stars" or "4,5" in "4,5 von 5 Sternen".
+var ratingNumberPattern = regexp.MustCompile(`\d+(?:[.,]\d+)?`)
+
+// ratingScalePattern matches the scale in a rating description, i.e., the
+// number of stars written right before the word for them, e.g., "5 stars" in
+// "4.5 out of 5 stars" or "5つ星" in "5つ星のうち4.5".
+var ratingScalePattern = regexp.MustCompile(`(?i)\d+\s*(?:つ星|stars|sternen|étoiles|stelle|estrellas|estrelas|sterren|stjärnor)`)
+
+// parseStarClass returns the number of stars shown by an icon with the given
+// classes. The second return value is false if none of the classes is a star
+// rating.
+func parseStarClass(classes string) (float64, bool) {
+	for _, class := range strings.Fields(classes) {
+		matches := starClassPattern.FindStringSubmatch(class)
+		if matches == nil {
+			continue
+		}
+
+		stars, _ := strconv.ParseFloat(matches[1], 64)
+		if matches[2] != "" {
+			stars += 0.5
+		}
+		if stars <= maxStars {
+			return stars, true
+		}
+	}
+	return 0, false
+}
+
+// ParseStars parses the number of stars, from 0 to 5, in a description of how
+// Amazon customers rated a product, such as "4.5 out of 5 stars", "4,5 von 5
+// Sternen" or "5つ星のうち4.5".
+func ParseStars(rating string) (float64, error) {
+	scale := ratingScalePattern.FindStringIndex(rating)
+
+	var numbers []string
+	for _, bounds := range ratingNumberPattern.FindAllStringIndex(rating, -1) {
+		if scale != nil && bounds[0] == scale[0] {
+			continue
+		}
+		numbers = append(numbers, rating[bounds[0]:bounds[1]])
+	}
+	if len(numbers) < 1 {
+		return 0, fmt.Errorf("No rating found in '%s'", rating)
+	}
+
+	// When no scale word is found, prefer the number written with a decimal
+	// separator, as Amazon writes the rating as, e.g., "4.0" but the scale as
+	// "5".
+	number := numbers[0]
+	if scale == nil {
+		for _, n := range numbers {
+			if strings.ContainsAny(n, ".,") {
+				number = n
+				break
+			}
+		}
+	}
+
+	stars, err := strconv.ParseFloat(strings.Replace(number, ",", ".", 1), 64)
+	if err != nil {
+		return 0, fmt.Errorf("Could not parse rating '%s': %s", rating, err)
+	}
+	if stars < 0 || stars > maxStars {
+		return 0, fmt.Errorf("Rating '%s' is not between 0 and %d stars", rating, maxStars)
+	}
+
+	return stars, nil
+}
@@ -0,0 +1,88 @@
+package amazon
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStars(t *testing.T) {
+	tests := []struct {
+		rating string
+		stars  float64
+	}{
+		{"4.0 out of 5 stars", 4},
+		{"4.5 out of 5 stars", 4.5},
+		{"5.0 out of 5 stars", 5},
+		{"4,5 von 5 Sternen", 4.5},
+		{"3,5 sur 5 étoiles", 3.5},
+		{"5つ星のうち4.5", 4.5},
+		{"5つ星のうち3.0", 3},
+		{"5つ星のうち4", 4},
+		{"5つ星のうち5", 5},
+		{"4 von 5 Sternen", 4},
+		{"5 out of 5 stars", 5},
+		{"5 de 5 estrellas", 5},
+		{"1 out of 5 stars", 1},
+	}
+
+	for _, test := range tests {
+		stars, err := ParseStars(test.rating)
+		require.NoError(t, err, test.rating)
+		require.Equal(t, test.stars, stars, test.rating)
+	}
+
+	_, err := ParseStars("no ratings yet")
+	require.Error(t, err)
+
+	_, err = ParseStars("7.0 out of 10 stars")
+	require.Error(t, err)
+}
+
+func TestParseStarClass(t *testing.T) {
+	stars, ok := parseStarClass("a-icon a-icon-star-small a-star-small-4")
+	require.True(t, ok)
+	require.Equal(t, 4.0, stars)
+
+	stars, ok = parseStarClass("a-icon a-icon-star-small a-star-small-4-5")
+	require.True(t, ok)
+	require.Equal(t, 4.5, stars)
+
+	stars, ok = parseStarClass("a-icon a-icon-star a-star-0")
+	require.True(t, ok)
+	require.Equal(t, 0.0, stars)
+
+	_, ok = parseStarClass("a-icon a-icon-popover")
+	require.False(t, ok)
+}
+
+func TestParseWishlistPageStars(t *testing.T) {
+	page, err := ParseWishlistPage(strings.NewReader(wishlistHTML), "https://www.amazon.com/hz/wishlist/ls/3I6EQPZ8OB1DT")
+	require.NoError(t, err)
+
+	item := page.Items["I2G6UJO0FYWV8J"]
+	require.Equal(t, "4.0 out of 5 stars", item.Rating)
+	require.Equal(t, 4.0, item.Stars)
+}
+
+func TestParseWishlistPageStarsFromClass(t *testing.T) {
+	html := strings.Replace(wishlistHTML, `a-star-small-4"><span class="a-icon-alt">4.0 out of 5 stars`,
+		`a-star-small-4-5"><span class="a-icon-alt">5つ星のうち4.5`, 1)
+
+	page, err := ParseWishlistPage(strings.NewReader(html), "https://www.amazon.co.jp/hz/wishlist/ls/3I6EQPZ8OB1DT")
+	require.NoError(t, err)
+
+	item := page.Items["I2G6UJO0FYWV8J"]
+	require.Equal(t, "5つ星のうち4.5", item.Rating)
+	require.Equal(t, 4.5, item.Stars)
+}
+
+func TestParseWishlistPageStarsFromText(t *testing.T) {
+	html := strings.Replace(wishlistHTML, `a-star-small-4"><span class="a-icon-alt">4.0 out of 5 stars`,
+		`"><span class="a-icon-alt">3,5 von 5 Sternen`, 1)
+
+	page, err := ParseWishlistPage(strings.NewReader(html), "https://www.amazon.de/hz/wishlist/ls/3I6EQPZ8OB1DT")
+	require.NoError(t, err)
+	require.Equal(t, 3.5, page.Items["I2G6UJO0FYWV8J"].Stars)
+}
@@ -0,0 +1,124 @@
+package amazon
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/gocolly/colly"
+)
+
+// Refresh brings a previous load of this wishlist's products, such as from
+// Items, up to date without loading every page again. As Amazon lists the most
+// recently added products first, pages are only loaded until one lists a
+// product already among the previous ones. The products found are merged with
+// the previous ones, which are not modified, and returned, as Items would
+// return them. Afterwards, Name, PrintURL and Items return what was found
+// without making further requests, as after Fetch.
+//
+// Previous products listed before the last one found again are dropped, as
+// they must have been removed from the wishlist since. So are all previous
+// products not found again when the last page of the wishlist was loaded.
+// Changes to previous products on pages that weren't loaded again are not
+// noticed. Refreshing a wishlist with MaxPages or MaxItems set is an error.
+func (w *Wishlist) Refresh(previous map[string]*Item) (map[string]*Item, error) {
+	return w.RefreshContext(context.Background(), previous)
+}
+
+// RefreshContext brings a previous load of this wishlist's products up to
+// date, as Refresh does. If the context is done before the refresh is
+// complete, the context's error is returned and the previous products are
+// returned unchanged.
+func (w *Wishlist) RefreshContext(ctx context.Context, previous map[string]*Item) (map[string]*Item, error) {
+	if w.Sort() != SortDateAdded {
+		return nil, errors.New("Wishlist must be sorted by date added to refresh it")
+	}
+
+	s := w.scraper(ctx)
+	if s.start != nil {
+		return nil, errors.New("Cannot refresh a wishlist resumed from a cursor")
+	}
+	if s.maxPages > 0 || s.maxItems > 0 {
+		return nil, errors.New("Cannot refresh a wishlist with MaxPages or MaxItems set")
+	}
+	s.known = previous
+	c := s.collector()
+
+	c.OnHTML("#profile-list-name", s.onName)
+	c.OnHTML("#wl-print-link", s.onPrintLink)
+	c.OnHTML("ul li", s.onListItem)
+	c.OnHTML("a.wl-see-more", func(link *colly.HTMLElement) {
+		s.onLoadMoreLink(c, link)
+	})
+
+	err := s.load(c)
+	if !s.keepResults(err) || ctx.Err() != nil {
+		w.setErrors(s.errors)
+		return previous, err
+	}
+
+	items := mergeItems(previous, s.items, s.stoppedAtKnown)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.urls = s.urls
+	w.items = items
+	w.errors = s.errors
+	w.warnings = s.warnings
+	w.cursor = s.cursor
+	w.name = s.name
+	w.printURL = s.printURL
+	w.fetched = true
+	w.fetchedAt = time.Now().UTC().Round(0)
+
+	result := make(map[string]*Item, len(items))
+	for id, item := range items {
+		result[id] = item
+	}
+	return result, err
+}
+
+// mergeItems combines the products found by refreshing a wishlist with those
+// found before. When the refresh stopped at a known product rather than at
+// the last page, previous products that were not found again are listed after
+// the found ones, in their previous order, unless they were listed before a
+// product that was found again. Otherwise only the found products are kept.
+func mergeItems(previous map[string]*Item, found map[string]*Item, stoppedAtKnown bool) map[string]*Item {
+	items := make(map[string]*Item, len(previous)+len(found))
+	if !stoppedAtKnown {
+		for id, item := range found {
+			items[id] = item
+		}
+		return items
+	}
+
+	lastFoundAgain := -1
+	for id, item := range found {
+		items[id] = item
+		if old, ok := previous[id]; ok && old.Position > lastFoundAgain {
+			lastFoundAgain = old.Position
+		}
+	}
+
+	rest := make([]*Item, 0, len(previous))
+	for id, item := range previous {
+		if _, ok := found[id]; !ok && item.Position > lastFoundAgain {
+			rest = append(rest, item)
+		}
+	}
+	sort.Slice(rest, func(i, j int) bool {
+		return rest[i].Position < rest[j].Position
+	})
+
+	position := len(found)
+	for _, item := range rest {
+		copied := *item
+		copied.Position = position
+		items[copied.ID] = &copied
+		position++
+	}
+
+	return items
+}
@@ -0,0 +1,136 @@
+package amazon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWishlistRefresh(t *testing.T) {
+	ts := newPagedTestServer(t, "123abc", []string{"I2G6UJO0FYWV8J"}, []string{"I1BXZQ7XJ0DSLV"}, []string{"I3K2PLM9XQ0AAB"})
+	defer ts.Close()
+
+	wishlist, err := NewWishlistFromIDAtDomain("123abc", ts.URL)
+	require.NoError(t, err)
+	wishlist.CacheResults = false
+
+	previous, err := wishlist.Items()
+	require.NoError(t, err)
+	require.Len(t, previous, 3)
+	require.Equal(t, 3, ts.requestCount())
+
+	ts.setPages([]string{"I4NEWITEM0001", "I2G6UJO0FYWV8J"}, []string{"I1BXZQ7XJ0DSLV"}, []string{"I3K2PLM9XQ0AAB"})
+
+	items, err := wishlist.Refresh(previous)
+	require.NoError(t, err)
+	require.Equal(t, 1, ts.requestCount())
+
+	list := sortItems(items)
+	require.Len(t, list, 4)
+	for i, id := range []string{"I4NEWITEM0001", "I2G6UJO0FYWV8J", "I1BXZQ7XJ0DSLV", "I3K2PLM9XQ0AAB"} {
+		require.Equal(t, id, list[i].ID)
+		require.Equal(t, i, list[i].Position)
+	}
+	require.Equal(t, 1, previous["I1BXZQ7XJ0DSLV"].Position, "should not modify previous items")
+
+	cached, err := wishlist.Items()
+	require.NoError(t, err)
+	require.Len(t, cached, 4)
+	require.Equal(t, 1, ts.requestCount(), "should not load the wishlist again")
+}
+
+func TestWishlistRefreshRemoved(t *testing.T) {
+	ts := newPagedTestServer(t, "123abc", []string{"I2G6UJO0FYWV8J", "I1BXZQ7XJ0DSLV"}, []string{"I3K2PLM9XQ0AAB"})
+	defer ts.Close()
+
+	wishlist, err := NewWishlistFromIDAtDomain("123abc", ts.URL)
+	require.NoError(t, err)
+	wishlist.CacheResults = false
+
+	previous, err := wishlist.Items()
+	require.NoError(t, err)
+	require.Len(t, previous, 3)
+
+	ts.setPages([]string{"I4NEWITEM0001", "I1BXZQ7XJ0DSLV"}, []string{"I3K2PLM9XQ0AAB"})
+
+	items, err := wishlist.Refresh(previous)
+	require.NoError(t, err)
+	require.Equal(t, 1, ts.requestCount())
+
+	list := sortItems(items)
+	require.Len(t, list, 3)
+	for i, id := range []string{"I4NEWITEM0001", "I1BXZQ7XJ0DSLV", "I3K2PLM9XQ0AAB"} {
+		require.Equal(t, id, list[i].ID)
+		require.Equal(t, i, list[i].Position)
+	}
+}
+
+func TestWishlistRefreshSorted(t *testing.T) {
+	wishlist, err := NewWishlistFromID("123abc")
+	require.NoError(t, err)
+	require.NoError(t, wishlist.SetSort(SortPriority))
+
+	_, err = wishlist.Refresh(map[string]*Item{})
+	require.Error(t, err)
+}
+
+func TestWishlistRefreshReachedEnd(t *testing.T) {
+	ts := newPagedTestServer(t, "123abc", []string{"I2G6UJO0FYWV8J", "I1BXZQ7XJ0DSLV"}, []string{"I3K2PLM9XQ0AAB"})
+	defer ts.Close()
+
+	wishlist, err := NewWishlistFromIDAtDomain("123abc", ts.URL)
+	require.NoError(t, err)
+	wishlist.CacheResults = false
+
+	previous, err := wishlist.Items()
+	require.NoError(t, err)
+	require.Len(t, previous, 3)
+
+	ts.setPages([]string{"I4NEWITEM0001"})
+
+	items, err := wishlist.Refresh(previous)
+	require.NoError(t, err)
+	require.Equal(t, 1, ts.requestCount())
+	require.Len(t, items, 1)
+	require.Contains(t, items, "I4NEWITEM0001")
+}
+
+func TestWishlistRefreshKnownOnLastPage(t *testing.T) {
+	ts := newPagedTestServer(t, "123abc", []string{"I2G6UJO0FYWV8J", "I1BXZQ7XJ0DSLV"}, []string{"I3K2PLM9XQ0AAB"})
+	defer ts.Close()
+
+	wishlist, err := NewWishlistFromIDAtDomain("123abc", ts.URL)
+	require.NoError(t, err)
+	wishlist.CacheResults = false
+
+	previous, err := wishlist.Items()
+	require.NoError(t, err)
+	require.Len(t, previous, 3)
+
+	ts.setPages([]string{"I4NEWITEM0001", "I2G6UJO0FYWV8J"})
+
+	items, err := wishlist.Refresh(previous)
+	require.NoError(t, err)
+	require.Equal(t, 1, ts.requestCount())
+
+	list := sortItems(items)
+	require.Len(t, list, 2)
+	for i, id := range []string{"I4NEWITEM0001", "I2G6UJO0FYWV8J"} {
+		require.Equal(t, id, list[i].ID)
+		require.Equal(t, i, list[i].Position)
+	}
+}
+
+func TestWishlistRefreshLimited(t *testing.T) {
+	wishlist, err := NewWishlistFromID("123abc")
+	require.NoError(t, err)
+	wishlist.MaxPages = 1
+
+	_, err = wishlist.Refresh(map[string]*Item{})
+	require.EqualError(t, err, "Cannot refresh a wishlist with MaxPages or MaxItems set")
+
+	wishlist.MaxPages = 0
+	wishlist.MaxItems = 10
+	_, err = wishlist.Refresh(map[string]*Item{})
+	require.Error(t, err)
+}
@@ -0,0 +1,40 @@
+package amazon
+
+import (
+	"fmt"
+)
+
+// RevealMode chooses which products a wishlist is loaded with, according to
+// whether they have been bought.
+type RevealMode string
+
+const (
+	// RevealUnpurchased loads only products that have yet to be bought, which
+	// is what Amazon shows by default.
+	RevealUnpurchased RevealMode = "unpurchased"
+
+	// RevealPurchased loads only products that have already been bought.
+	RevealPurchased RevealMode = "purchased"
+
+	// RevealAll loads every product, whether or not it has been bought.
+	RevealAll RevealMode = "all"
+)
+
+// revealQueryParam is the query parameter of a wishlist URL that chooses its
+// RevealMode.
+const revealQueryParam = "reveal"
+
+// ParseRevealMode returns the RevealMode with the given name, such as "all".
+func ParseRevealMode(name string) (RevealMode, error) {
+	mode := RevealMode(name)
+	switch mode {
+	case RevealUnpurchased, RevealPurchased, RevealAll:
+		return mode, nil
+	}
+	return "", fmt.Errorf("Unknown reveal mode '%s', expected one of %s, %s or %s",
+		name, RevealUnpurchased, RevealPurchased, RevealAll)
+}
+
+func (m RevealMode) String() string {
+	return string(m)
+}
@@ -0,0 +1,80 @@
+package amazon
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRevealMode(t *testing.T) {
+	for _, name := range []string{"unpurchased", "purchased", "all"} {
+		mode, err := ParseRevealMode(name)
+		require.NoError(t, err)
+		require.Equal(t, name, mode.String())
+	}
+
+	_, err := ParseRevealMode("bought")
+	require.Error(t, err)
+}
+
+func TestWishlistSetReveal(t *testing.T) {
+	wishlist, err := NewWishlistFromID("123abc")
+	require.NoError(t, err)
+	require.Equal(t, RevealUnpurchased, wishlist.Reveal())
+	require.Contains(t, wishlist.URLs()[0], "reveal=unpurchased")
+
+	require.NoError(t, wishlist.SetReveal(RevealAll))
+	require.Equal(t, RevealAll, wishlist.Reveal())
+	require.Equal(t, []string{"https://www.amazon.com/hz/wishlist/ls/123abc?reveal=all&sort=date&layout=standard&viewType=list&filter=DEFAULT&type=wishlist"},
+		wishlist.URLs())
+
+	require.Error(t, wishlist.SetReveal(RevealMode("bought")))
+	require.Equal(t, RevealAll, wishlist.Reveal())
+}
+
+func TestItemsRevealPurchased(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hz/wishlist/ls/123abc", func(w http.ResponseWriter, r *http.Request) {
+		html := wishlistHTML
+		if r.URL.Query().Get("reveal") == "unpurchased" {
+			html = strings.Replace(html, "I2G6UJO0FYWV8J", "I1BXZQ7XJ0DSLV", -1)
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	wishlist, err := NewWishlistFromIDAtDomain("123abc", ts.URL)
+	require.NoError(t, err)
+	wishlist.CacheResults = false
+
+	items, err := wishlist.Items()
+	require.NoError(t, err)
+	require.Contains(t, items, "I1BXZQ7XJ0DSLV")
+	require.False(t, items["I1BXZQ7XJ0DSLV"].Purchased, "should not be purchased")
+
+	require.NoError(t, wishlist.SetReveal(RevealPurchased))
+	items, err = wishlist.Items()
+	require.NoError(t, err)
+	require.Contains(t, items, "I2G6UJO0FYWV8J")
+	require.True(t, items["I2G6UJO0FYWV8J"].Purchased, "should be purchased")
+	require.Contains(t, items["I2G6UJO0FYWV8J"].String(), "\tPurchased\n")
+}
+
+func TestParseWishlistPagePurchased(t *testing.T) {
+	baseURL := "https://www.amazon.com/hz/wishlist/ls/3I6EQPZ8OB1DT?reveal=all"
+
+	page, err := ParseWishlistPage(strings.NewReader(wishlistHTML), baseURL)
+	require.NoError(t, err)
+	require.False(t, page.Items["I2G6UJO0FYWV8J"].Purchased, "should have 11 of 50")
+
+	html := strings.Replace(wishlistHTML, `<span id="itemRequested_I2G6UJO0FYWV8J">50</span>`,
+		`<span id="itemRequested_I2G6UJO0FYWV8J">11</span>`, 1)
+	page, err = ParseWishlistPage(strings.NewReader(html), baseURL)
+	require.NoError(t, err)
+	require.True(t, page.Items["I2G6UJO0FYWV8J"].Purchased, "should have 11 of 11")
+}
@@ -0,0 +1,766 @@
+package amazon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gocolly/colly"
+	"github.com/gocolly/colly/extensions"
+	"github.com/gocolly/colly/proxy"
+)
+
+// asinPattern matches an Amazon Standard Identification Number.
+var asinPattern = regexp.MustCompile(`^[A-Z0-9]{10}$`)
+
+// asinPathPattern matches the ASIN in the path of a product's URL, e.g.,
+// "/dp/B0018CLTKE/".
+var asinPathPattern = regexp.MustCompile(`/(?:dp|gp/product)/([A-Z0-9]{10})(?:[/?]|$)`)
+
+// repositionData is the JSON Amazon attaches to a product's list item.
+type repositionData struct {
+	ItemExternalID string `json:"itemExternalId"`
+}
+
+// scraper holds the state of a single crawl of an Amazon wishlist. Pages are
+// loaded in parallel, so everything it finds is guarded by a mutex.
+type scraper struct {
+	ctx            context.Context
+	id             string
+	debugMode      bool
+	cacheResults   bool
+	partialResults bool
+	proxyURLs      []string
+	marketplace    *Marketplace
+	reveal         RevealMode
+	maxPages       int
+	maxItems       int
+
+	// start, when set, is where the crawl resumes from.
+	start *Cursor
+
+	// proxyTransport, when set, is the transport made for this crawl to use
+	// its proxies. Its connections are closed once the crawl finishes.
+	proxyTransport *http.Transport
+
+	// known, when set, are the products found by a previous crawl. No more
+	// pages are loaded once one of them is found again.
+	known map[string]*Item
+
+	// stream, when set, receives each product as soon as it is complete,
+	// instead of the product being kept in items.
+	stream chan<- *Item
+
+	mu         sync.Mutex
+	errors     []error
+	warnings   []*FieldError
+	urls       []string
+	items      map[string]*Item
+	name       string
+	printURL   string
+	found      int
+	cursor     *Cursor
+	foundKnown bool
+
+	// stoppedAtKnown is whether a next page was left unloaded because a known
+	// product had been found.
+	stoppedAtKnown bool
+}
+
+// newScraper constructs a scraper that will crawl starting from the given URL.
+func newScraper(ctx context.Context, id string, wishlistURL string) *scraper {
+	return &scraper{
+		ctx:         ctx,
+		id:          id,
+		proxyURLs:   []string{},
+		marketplace: marketplaceFor(wishlistURL),
+		reveal:      revealModeFor(wishlistURL),
+		errors:      []error{},
+		warnings:    []*FieldError{},
+		urls:        []string{wishlistURL},
+		items:       map[string]*Item{},
+	}
+}
+
+// revealModeFor returns which products the given wishlist URL loads,
+// according to whether they have been bought.
+func revealModeFor(wishlistURL string) RevealMode {
+	if uri, err := url.Parse(wishlistURL); err == nil {
+		if mode, err := ParseRevealMode(uri.Query().Get(revealQueryParam)); err == nil {
+			return mode
+		}
+	}
+	return RevealUnpurchased
+}
+
+func (s *scraper) load(c *colly.Collector) error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+
+	if s.debugMode {
+		fmt.Println("Using URL", s.urls[0])
+	}
+
+	position := 0
+	if s.start != nil {
+		position = s.start.Position
+	}
+	if err := c.Request("GET", s.urls[0], nil, newPageContext(position), nil); err != nil {
+		return err
+	}
+
+	c.Wait()
+	if s.proxyTransport != nil {
+		s.proxyTransport.CloseIdleConnections()
+	}
+
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+
+	if len(s.errors) > 0 {
+		if s.partialResults {
+			return &MultiError{Errors: s.errors}
+		}
+		return s.errors[0]
+	}
+
+	return nil
+}
+
+// keepResults reports whether whatever was found should be returned along
+// with the given error from loading the wishlist.
+func (s *scraper) keepResults(err error) bool {
+	return err == nil || s.ctx.Err() != nil || s.partialResults
+}
+
+func (s *scraper) collector() *colly.Collector {
+	options := []func(*colly.Collector){colly.Async(true)}
+	if s.cacheResults {
+		if s.debugMode {
+			fmt.Println("Caching Amazon responses in", cachePath)
+		}
+		options = append(options, colly.CacheDir(cachePath))
+	}
+	c := colly.NewCollector(options...)
+
+	extensions.RandomUserAgent(c)
+	c.Limit(&colly.LimitRule{
+		RandomDelay: 2 * time.Second,
+		Parallelism: 4,
+	})
+
+	transport := http.DefaultTransport
+	if len(s.proxyURLs) > 0 {
+		s.proxyTransport = http.DefaultTransport.(*http.Transport).Clone()
+		s.applyProxies(s.proxyTransport)
+		transport = s.proxyTransport
+	}
+	c.WithTransport(&contextTransport{ctx: s.ctx, transport: transport})
+
+	c.OnRequest(s.onRequest)
+	c.OnResponse(s.onResponse)
+	c.OnError(s.onError)
+	c.OnHTML("html", s.onPage)
+
+	return c
+}
+
+func (s *scraper) addError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.errors = append(s.errors, err)
+}
+
+func (s *scraper) addFieldError(item *Item, field string, el *colly.HTMLElement, err error) {
+	s.addError(&FieldError{
+		URL:    el.Request.URL.String(),
+		ItemID: item.ID,
+		Field:  field,
+		Err:    err,
+	})
+}
+
+// addFieldWarning records a detail of a product that could not be parsed but
+// is not important enough to fail loading the wishlist over.
+func (s *scraper) addFieldWarning(item *Item, field string, el *colly.HTMLElement, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.warnings = append(s.warnings, &FieldError{
+		URL:    el.Request.URL.String(),
+		ItemID: item.ID,
+		Field:  field,
+		Err:    err,
+	})
+}
+
+func (s *scraper) onRequest(r *colly.Request) {
+	if s.ctx.Err() != nil {
+		r.Abort()
+		return
+	}
+
+	if s.debugMode {
+		fmt.Println("Using User-Agent", r.Headers.Get("User-Agent"))
+	}
+	r.Headers.Set("cookie", "i18n-prefs="+s.marketplace.Currency)
+	r.Headers.Set("Accept-Language", s.marketplace.Language)
+}
+
+func (s *scraper) onResponse(r *colly.Response) {
+	if s.debugMode {
+		fmt.Printf("Status %d\n", r.StatusCode)
+	}
+
+	if s.debugMode {
+		filename := fmt.Sprintf("wishlist-%s-%s.html", s.id, r.FileName())
+		fmt.Printf("Saving wishlist HTML source to %s...\n", filename)
+		if err := r.Save(filename); err != nil {
+			s.addError(err)
+		}
+	}
+}
+
+func (s *scraper) onError(r *colly.Response, err error) {
+	s.addError(responseError(s.marketplace, r.Request.URL.String(), r.StatusCode, r.Body, err))
+}
+
+func (s *scraper) onPage(page *colly.HTMLElement) {
+	if err := pageError(s.marketplace, page.Request.URL, page.Response.StatusCode, page.DOM); err != nil {
+		s.addError(err)
+	}
+}
+
+func (s *scraper) onName(el *colly.HTMLElement) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.name = strings.TrimSpace(el.Text)
+}
+
+func (s *scraper) onPrintLink(link *colly.HTMLElement) {
+	relativeURL := link.Attr("href")
+	if len(relativeURL) < 1 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.printURL = link.Request.AbsoluteURL(relativeURL)
+}
+
+func (s *scraper) onLoadMoreLink(c *colly.Collector, link *colly.HTMLElement) {
+	if s.ctx.Err() != nil {
+		return
+	}
+
+	relativeURL := link.Attr("href")
+	if len(relativeURL) < 1 {
+		return
+	}
+
+	nextPageURL := link.Request.AbsoluteURL(relativeURL)
+	position := currentPosition(link.Request.Ctx)
+
+	s.mu.Lock()
+	if s.cursor != nil {
+		s.mu.Unlock()
+		return
+	}
+	if s.foundKnown {
+		s.stoppedAtKnown = true
+		s.mu.Unlock()
+		return
+	}
+	if (s.maxPages > 0 && len(s.urls) >= s.maxPages) || (s.maxItems > 0 && s.found >= s.maxItems) {
+		s.cursor = newCursor(nextPageURL, position, 0)
+		s.mu.Unlock()
+		return
+	}
+	s.urls = append(s.urls, nextPageURL)
+	s.mu.Unlock()
+
+	if s.debugMode {
+		fmt.Println("Found URL to next page", nextPageURL)
+	}
+
+	c.Request("GET", nextPageURL, nil, newPageContext(position), nil)
+}
+
+// onListItem builds a product from everything within its list item, and only
+// adds it to the scraper's items once it is complete.
+func (s *scraper) onListItem(listItem *colly.HTMLElement) {
+	id := listItem.Attr("data-itemid")
+	if len(id) < 1 {
+		return
+	}
+
+	var item *Item
+	listItem.ForEach("a", func(index int, link *colly.HTMLElement) {
+		item = s.onLink(id, item, link)
+	})
+	if item == nil {
+		return
+	}
+
+	listItem.ForEach(".a-price", func(index int, priceEl *colly.HTMLElement) {
+		s.onPrice(item, priceEl)
+	})
+	listItem.ForEach(".itemUsedAndNewPrice", func(index int, priceEl *colly.HTMLElement) {
+		s.onBackupPrice(item, priceEl)
+	})
+	s.onPriceAmount(item, listItem)
+	listItem.ForEach(".itemUsedAndNew", func(index int, container *colly.HTMLElement) {
+		s.onUsedAndNewContainer(item, container)
+	})
+	listItem.ForEach(".dateAddedText", func(index int, container *colly.HTMLElement) {
+		s.onDateAddedContainer(item, container)
+	})
+	listItem.ForEach("[data-action='add-to-cart']", func(index int, container *colly.HTMLElement) {
+		s.onAddToCartContainer(item, container)
+	})
+	listItem.ForEach(".g-itemImage", func(index int, container *colly.HTMLElement) {
+		s.onImageContainer(item, container)
+	})
+	listItem.ForEach(".reviewStarsPopoverLink", func(index int, container *colly.HTMLElement) {
+		s.onRatingContainer(item, container)
+	})
+	listItem.ForEach(".a-icon-prime", func(index int, primeIndicator *colly.HTMLElement) {
+		s.onPrime(item, primeIndicator)
+	})
+	listItem.ForEach("span", func(index int, span *colly.HTMLElement) {
+		s.onSpan(item, span)
+	})
+	listItem.ForEach(".g-item-details span.a-size-small", func(index int, span *colly.HTMLElement) {
+		s.onAttributeSpan(item, span)
+	})
+	s.onASIN(item, listItem)
+	item.Purchased = s.reveal == RevealPurchased || item.isPurchased()
+
+	item.Position = nextPosition(listItem.Request.Ctx)
+	if !s.admit(item, listItem.Request) {
+		return
+	}
+
+	if s.stream != nil {
+		s.send(item)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.items[id] = item
+}
+
+// admit reports whether the given product should be loaded, i.e., it wasn't
+// loaded before the crawl being resumed stopped, and MaxItems hasn't been
+// reached. If it has, where the crawl stopped is recorded.
+func (s *scraper) admit(item *Item, r *colly.Request) bool {
+	if s.start != nil && item.Position < s.start.Position+s.start.Skip {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.known[item.ID]; ok {
+		s.foundKnown = true
+	}
+
+	if s.maxItems > 0 && s.found >= s.maxItems {
+		if s.cursor == nil {
+			start := pageStart(r.Ctx)
+			s.cursor = newCursor(r.URL.String(), start, item.Position-start)
+		}
+		return false
+	}
+
+	s.found++
+	return true
+}
+
+// send passes the given product to whoever is reading the scraper's stream,
+// waiting until they are ready for it unless the crawl is stopped first.
+func (s *scraper) send(item *Item) {
+	select {
+	case s.stream <- item:
+	case <-s.ctx.Done():
+	}
+}
+
+func (s *scraper) onSpan(item *Item, span *colly.HTMLElement) {
+	spanID := span.Attr("id")
+	if len(spanID) < 1 {
+		return
+	}
+
+	if strings.HasPrefix(spanID, requestCountIDPrefix) {
+		s.onRequestedCountSpan(item, span)
+	} else if strings.HasPrefix(spanID, ownedCountIDPrefix) {
+		s.onOwnedCountSpan(item, span)
+	} else if strings.HasPrefix(spanID, priorityLabelPrefix) {
+		s.onPriorityLabelSpan(item, span)
+	} else if strings.HasPrefix(spanID, priorityIDPrefix) {
+		s.onPrioritySpan(item, span)
+	} else if strings.HasPrefix(spanID, commentIDPrefix) {
+		s.onCommentSpan(item, span)
+	} else if strings.HasPrefix(spanID, bylineIDPrefix) {
+		s.onBylineSpan(item, span)
+	}
+}
+
+func (s *scraper) onBylineSpan(item *Item, span *colly.HTMLElement) {
+	item.Byline = ParseByline(span.Text)
+}
+
+// onPriorityLabelSpan reads the product's priority from the class of its
+// label, which is the same in every language. The numeric priority, if
+// present, takes precedence.
+func (s *scraper) onPriorityLabelSpan(item *Item, span *colly.HTMLElement) {
+	for _, class := range strings.Fields(span.Attr("class")) {
+		if !strings.HasPrefix(class, priorityClassPrefix) {
+			continue
+		}
+
+		if priority, ok := ParsePriority(strings.TrimPrefix(class, priorityClassPrefix)); ok {
+			item.Priority = priority
+		}
+	}
+}
+
+func (s *scraper) onPrioritySpan(item *Item, span *colly.HTMLElement) {
+	priorityStr := strings.TrimSpace(span.Text)
+	if len(priorityStr) < 1 {
+		return
+	}
+
+	priority, err := strconv.ParseInt(priorityStr, 10, 64)
+	if err != nil {
+		s.addFieldError(item, "Priority", span, err)
+		return
+	}
+
+	item.Priority = Priority(priority)
+}
+
+func (s *scraper) onCommentSpan(item *Item, span *colly.HTMLElement) {
+	item.Comment = strings.TrimSpace(span.Text)
+}
+
+// onAttributeSpan reads a variation attribute such as "Size : Medium". Other
+// small text in the product's details, such as its priority, is identified by
+// an ID and skipped.
+func (s *scraper) onAttributeSpan(item *Item, span *colly.HTMLElement) {
+	if span.Attr("id") != "" {
+		return
+	}
+
+	text := strings.TrimSpace(span.Text)
+	separator := strings.IndexAny(text, attributeSeparators)
+	if separator < 0 {
+		return
+	}
+
+	_, width := utf8.DecodeRuneInString(text[separator:])
+	name := strings.TrimSpace(text[:separator])
+	value := strings.TrimSpace(text[separator+width:])
+	if name == "" || value == "" {
+		return
+	}
+
+	item.Attributes = append(item.Attributes, Attribute{Name: name, Value: value})
+}
+
+func (s *scraper) onRequestedCountSpan(item *Item, span *colly.HTMLElement) {
+	requestedCountStr := span.Text
+	if len(requestedCountStr) < 1 {
+		return
+	}
+
+	requestedCount, err := strconv.ParseInt(requestedCountStr, 10, 64)
+	if err != nil {
+		s.addFieldError(item, "RequestedCount", span, err)
+		return
+	}
+
+	item.RequestedCount = int(requestedCount)
+}
+
+func (s *scraper) onOwnedCountSpan(item *Item, span *colly.HTMLElement) {
+	ownedCountStr := span.Text
+	if len(ownedCountStr) < 1 {
+		return
+	}
+
+	ownedCount, err := strconv.ParseInt(ownedCountStr, 10, 64)
+	if err != nil {
+		s.addFieldError(item, "OwnedCount", span, err)
+		return
+	}
+
+	item.OwnedCount = int(ownedCount)
+}
+
+func (s *scraper) onPrime(item *Item, primeIndicator *colly.HTMLElement) {
+	item.IsPrime = true
+}
+
+func (s *scraper) onRatingContainer(item *Item, container *colly.HTMLElement) {
+	container.ForEach(".a-icon-alt", func(index int, ratingEl *colly.HTMLElement) {
+		s.onRating(item, ratingEl)
+	})
+	container.ForEach("i.a-icon", func(index int, icon *colly.HTMLElement) {
+		s.onRatingIcon(item, icon)
+	})
+}
+
+func (s *scraper) onRating(item *Item, ratingEl *colly.HTMLElement) {
+	item.Rating = strings.TrimSpace(ratingEl.Text)
+
+	if stars, err := ParseStars(item.Rating); err == nil {
+		item.Stars = stars
+	} else if s.debugMode {
+		fmt.Printf("Could not parse stars of item %s: %s\n", item.ID, err)
+	}
+}
+
+// onRatingIcon reads the product's stars from the class of its star icon,
+// which doesn't depend on the language of the page, in preference to the
+// rating's description.
+func (s *scraper) onRatingIcon(item *Item, icon *colly.HTMLElement) {
+	if stars, ok := parseStarClass(icon.Attr("class")); ok {
+		item.Stars = stars
+	}
+}
+
+func (s *scraper) onImageContainer(item *Item, container *colly.HTMLElement) {
+	container.ForEach("img", func(index int, image *colly.HTMLElement) {
+		s.onImage(item, image)
+	})
+}
+
+func (s *scraper) onImage(item *Item, image *colly.HTMLElement) {
+	relativeURL := image.Attr("src")
+	if len(relativeURL) < 1 {
+		return
+	}
+
+	item.ImageURL = image.Request.AbsoluteURL(relativeURL)
+}
+
+func (s *scraper) onAddToCartContainer(item *Item, container *colly.HTMLElement) {
+	var data addToCartData
+	if err := json.Unmarshal([]byte(container.Attr("data-add-to-cart")), &data); err == nil {
+		if asinPattern.MatchString(data.ASIN) {
+			item.ASIN = data.ASIN
+		}
+		if asinPattern.MatchString(data.CanonicalASIN) {
+			item.CanonicalASIN = data.CanonicalASIN
+		}
+		item.Offer = data.offer(s.marketplace)
+	}
+
+	container.ForEach("a", func(index int, link *colly.HTMLElement) {
+		s.onAddToCartLink(item, link)
+	})
+}
+
+func (s *scraper) onAddToCartLink(item *Item, link *colly.HTMLElement) {
+	linkText := strings.ToLower(link.Text)
+	if !strings.Contains(linkText, addToCartText) {
+		return
+	}
+
+	relativeURL := link.Attr("href")
+	if len(relativeURL) < 1 {
+		return
+	}
+
+	item.AddToCartURL = link.Request.AbsoluteURL(relativeURL)
+}
+
+// onASIN fills in the product's ASIN from its list item or URL, if it wasn't
+// found on its add to cart button.
+func (s *scraper) onASIN(item *Item, listItem *colly.HTMLElement) {
+	if item.ASIN == "" {
+		var data repositionData
+		if err := json.Unmarshal([]byte(listItem.Attr("data-reposition-action-params")), &data); err == nil {
+			asin := strings.SplitN(strings.TrimPrefix(data.ItemExternalID, asinPrefix), "|", 2)[0]
+			if asinPattern.MatchString(asin) {
+				item.ASIN = asin
+			}
+		}
+	}
+
+	if item.ASIN == "" {
+		if match := asinPathPattern.FindStringSubmatch(item.DirectURL); match != nil {
+			item.ASIN = match[1]
+		}
+	}
+
+	if item.CanonicalASIN == "" {
+		item.CanonicalASIN = item.ASIN
+	}
+}
+
+func (s *scraper) onReviewCountLink(item *Item, link *colly.HTMLElement) {
+	if strings.TrimSpace(link.Text) != "" {
+		if reviewCount, err := parseCount(link.Text); err == nil {
+			item.ReviewCount = reviewCount
+		} else {
+			s.addFieldWarning(item, "ReviewCount", link, err)
+		}
+	}
+
+	relativeURL := link.Attr("href")
+	if relativeURL != "" {
+		item.ReviewsURL = link.Request.AbsoluteURL(relativeURL)
+	}
+}
+
+// onLink returns the product the given link belongs to, which is created
+// anew when the link has a title.
+func (s *scraper) onLink(id string, item *Item, link *colly.HTMLElement) *Item {
+	linkID := link.Attr("id")
+	if len(linkID) > 0 && strings.HasPrefix(linkID, reviewCountIDPrefix) {
+		if item != nil {
+			s.onReviewCountLink(item, link)
+		}
+		return item
+	}
+
+	title := link.Attr("title")
+	if len(title) < 1 {
+		return item
+	}
+
+	relativeURL := link.Attr("href")
+	if len(relativeURL) < 1 {
+		return item
+	}
+
+	item = NewItem(id, title, link.Request.AbsoluteURL(relativeURL))
+	item.marketplace = s.marketplace
+	return item
+}
+
+func (s *scraper) onPrice(item *Item, priceEl *colly.HTMLElement) {
+	item.Price = priceEl.ChildText(".a-offscreen")
+}
+
+func (s *scraper) onBackupPrice(item *Item, priceEl *colly.HTMLElement) {
+	if item.Price != "" {
+		return
+	}
+
+	item.Price = priceEl.Text
+}
+
+// onUsedAndNewContainer summarizes the product's other new and used listings
+// from a link such as "6 Used & New" and the price shown after it.
+func (s *scraper) onUsedAndNewContainer(item *Item, container *colly.HTMLElement) {
+	container.ForEach("a", func(index int, link *colly.HTMLElement) {
+		if !strings.HasPrefix(link.Attr("id"), usedAndNewIDPrefix) {
+			return
+		}
+
+		summary := &OffersSummary{}
+		if relativeURL := link.Attr("href"); relativeURL != "" {
+			summary.URL = link.Request.AbsoluteURL(relativeURL)
+		}
+
+		if count, err := parseCount(link.Text); err == nil {
+			summary.Count = count
+		} else if s.debugMode {
+			fmt.Printf("Could not parse offer count of item %s: %s\n", item.ID, err)
+		}
+
+		if price, err := ParseMoney(container.ChildText(".itemUsedAndNewPrice"), s.marketplace.Currency); err == nil {
+			summary.LowestPrice = price
+		}
+
+		item.OffersSummary = summary
+	})
+}
+
+// onPriceAmount parses the product's displayed price, falling back to the
+// machine-readable price on its list item if that can't be parsed.
+func (s *scraper) onPriceAmount(item *Item, listItem *colly.HTMLElement) {
+	if low, high, err := ParsePriceRange(item.Price, s.marketplace.Currency); err == nil {
+		item.PriceAmount = low
+		item.MaxPriceAmount = high
+
+		if s.debugMode {
+			if data, err := ParseMoney(listItem.Attr("data-price"), low.Currency); err == nil && data.Amount != low.Amount {
+				fmt.Printf("Price %s of item %s differs from data-price %s\n", low, item.ID, data)
+			}
+		}
+		return
+	}
+
+	if data, err := ParseMoney(listItem.Attr("data-price"), s.marketplace.Currency); err == nil {
+		item.PriceAmount = data
+		item.MaxPriceAmount = data
+	}
+}
+
+func (s *scraper) onDateAddedContainer(item *Item, container *colly.HTMLElement) {
+	container.ForEach("span", func(index int, span *colly.HTMLElement) {
+		spanID := span.Attr("id")
+		if len(spanID) < 1 {
+			return
+		}
+		if !strings.HasPrefix(spanID, dateAddedIDPrefix) {
+			return
+		}
+		s.onDateAdded(item, span)
+	})
+}
+
+func (s *scraper) onDateAdded(item *Item, dateEl *colly.HTMLElement) {
+	item.RawDateAdded = s.marketplace.trimDateAdded(dateEl.Text)
+}
+
+// currentPosition returns the position the next product found on a page will
+// have, which for a page not yet searched is where the previous page left off.
+func currentPosition(ctx *colly.Context) int {
+	position, _ := ctx.GetAny(positionKey).(int)
+	return position
+}
+
+// nextPosition returns the position of the product just found on a page and
+// advances the page's position past it. A page's products are found in the
+// order they appear, before any link to the next page is followed.
+func nextPosition(ctx *colly.Context) int {
+	position := currentPosition(ctx)
+	ctx.Put(positionKey, position+1)
+	return position
+}
+
+func (s *scraper) applyProxies(transport *http.Transport) error {
+	if s.debugMode {
+		fmt.Printf("Using proxies: %v\n", s.proxyURLs)
+	}
+
+	proxySwitcher, err := proxy.RoundRobinProxySwitcher(s.proxyURLs...)
+	if err != nil {
+		return err
+	}
+
+	transport.Proxy = proxySwitcher
+
+	return nil
+}
@@ -0,0 +1,422 @@
+package amazon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// SnapshotVersion is the version of the JSON schema Snapshot is written in.
+// It is increased whenever the schema changes in a way older readers would
+// misunderstand, and LoadSnapshot keeps reading every earlier version.
+//
+// Version history:
+//   - 0: no "version" key; the JSON encoding of the map returned by Items,
+//     keyed by item ID, with Go's default field names. It doesn't record the
+//     Amazon store, so DateAdded is only set when RawDateAdded is in the
+//     format amazon.com uses, e.g., "July 10, 2019".
+//   - 1: the Snapshot and SnapshotItem types.
+const SnapshotVersion = 1
+
+// snapshotDateLayout is how dates are written in a snapshot.
+const snapshotDateLayout = "2006-01-02"
+
+// Snapshot is everything loaded from a wishlist at a point in time, in a form
+// that can be saved as JSON and read back, including by programs written in
+// other languages. Its JSON schema is versioned by SnapshotVersion.
+type Snapshot struct {
+	// Version is the SnapshotVersion the snapshot was written with.
+	Version int `json:"version"`
+
+	// WishlistID identifies the wishlist on Amazon.
+	WishlistID string `json:"wishlistId"`
+
+	// Domain is the Amazon domain the wishlist is at, e.g.,
+	// "https://www.amazon.com".
+	Domain string `json:"domain"`
+
+	// Name is the name of the wishlist.
+	Name string `json:"name"`
+
+	// PrintURL is the URL to the printer-friendly view of the wishlist.
+	PrintURL string `json:"printUrl,omitempty"`
+
+	// URLs are the pages of the wishlist that were loaded.
+	URLs []string `json:"urls"`
+
+	// FetchedAt is when the wishlist was loaded.
+	FetchedAt time.Time `json:"fetchedAt"`
+
+	// Items are the products on the wishlist in the order Amazon displays
+	// them.
+	Items []*SnapshotItem `json:"items"`
+}
+
+// SnapshotItem is a product on a wishlist as written in a Snapshot. Its fields
+// are those of Item, with details that are unknown left out of its JSON rather
+// than written as placeholder values.
+type SnapshotItem struct {
+	// ID is a unique identifier for this product on the wishlist.
+	ID string `json:"id"`
+
+	// ASIN is the Amazon Standard Identification Number of this product, or
+	// is omitted if Amazon didn't show it.
+	ASIN string `json:"asin,omitempty"`
+
+	// CanonicalASIN is the ASIN Amazon considers the main listing of this
+	// product, or is omitted if Amazon didn't show it.
+	CanonicalASIN string `json:"canonicalAsin,omitempty"`
+
+	// Position is the zero-based index of this product in the wishlist, in the
+	// order Amazon displays them across all pages.
+	Position int `json:"position"`
+
+	// Name is the name of this product.
+	Name string `json:"name"`
+
+	// Byline describes who made this product and in what format, or is
+	// omitted if Amazon doesn't say.
+	Byline *Byline `json:"byline,omitempty"`
+
+	// DirectURL is the absolute URL to view this product on Amazon.
+	DirectURL string `json:"directUrl,omitempty"`
+
+	// AddToCartURL is the absolute URL to add this product to a shopping cart
+	// from the wishlist, or is omitted if it can't be added.
+	AddToCartURL string `json:"addToCartUrl,omitempty"`
+
+	// ImageURL is the absolute URL of an image of this product, or is omitted
+	// if there is none.
+	ImageURL string `json:"imageUrl,omitempty"`
+
+	// ReviewsURL is the absolute URL to the customer reviews of this product,
+	// or is omitted if it has none.
+	ReviewsURL string `json:"reviewsUrl,omitempty"`
+
+	// Price is the price as Amazon displays it, e.g., "$15.96" or
+	// "$10.99 - $24.99", or is omitted if Amazon showed none.
+	Price string `json:"price,omitempty"`
+
+	// PriceAmount is Price parsed, with its amount in the currency's minor
+	// units, e.g., cents, or is omitted if it couldn't be parsed. When Price
+	// is a range, this is the low end of it.
+	PriceAmount *Money `json:"priceAmount,omitempty"`
+
+	// MaxPriceAmount is the high end of the range when Price is a range, and
+	// otherwise the same as PriceAmount.
+	MaxPriceAmount *Money `json:"maxPriceAmount,omitempty"`
+
+	// Offer describes the listing adding this product to a cart would buy, or
+	// is omitted if it can't be added.
+	Offer *Offer `json:"offer,omitempty"`
+
+	// OffersSummary describes the other new and used listings of this
+	// product, or is omitted if Amazon doesn't mention any.
+	OffersSummary *OffersSummary `json:"offersSummary,omitempty"`
+
+	// IsPrime is whether the product is eligible for Amazon Prime shipping.
+	IsPrime bool `json:"isPrime"`
+
+	// RequestedCount is how many of the product the wishlist recipient would
+	// like, or is omitted if Amazon doesn't show it.
+	RequestedCount *int `json:"requestedCount,omitempty"`
+
+	// OwnedCount is how many of the product the wishlist recipient already
+	// has, or is omitted if Amazon doesn't show it.
+	OwnedCount *int `json:"ownedCount,omitempty"`
+
+	// Purchased is whether the product has already been bought.
+	Purchased bool `json:"purchased"`
+
+	// Priority is how much the wishlist recipient wants this product: one of
+	// "lowest", "low", "medium", "high", or "highest". A priority Amazon
+	// doesn't name is written as its number, e.g., "3".
+	Priority string `json:"priority"`
+
+	// Comment is the wishlist recipient's note about this product, or is
+	// omitted if there is none.
+	Comment string `json:"comment,omitempty"`
+
+	// Attributes describe the variation of this product that is wanted, such
+	// as its size, in the order Amazon lists them, or are omitted if none.
+	Attributes []Attribute `json:"attributes,omitempty"`
+
+	// RawDateAdded is when the product was added to the wishlist as Amazon
+	// displays it, e.g., "July 10, 2019", or is omitted if Amazon didn't.
+	RawDateAdded string `json:"rawDateAdded,omitempty"`
+
+	// DateAdded is RawDateAdded as "YYYY-MM-DD", or is omitted if it couldn't
+	// be parsed.
+	DateAdded string `json:"dateAdded,omitempty"`
+
+	// Rating is how customers have rated this product as Amazon describes it,
+	// e.g., "4.0 out of 5 stars", or is omitted if it hasn't been rated.
+	Rating string `json:"rating,omitempty"`
+
+	// Stars is Rating as a number from 0 to 5 in steps of a half star, or is
+	// omitted if the product hasn't been rated.
+	Stars float64 `json:"stars,omitempty"`
+
+	// ReviewCount is how many customer reviews the product has, or 0 if none.
+	ReviewCount int `json:"reviewCount"`
+}
+
+// snapshotLoaders read each version of a snapshot's JSON schema.
+var snapshotLoaders = map[int]func(data []byte) (*Snapshot, error){
+	0: loadSnapshotV0,
+	1: loadSnapshotV1,
+}
+
+// Snapshot returns everything loaded from this wishlist, loading it with
+// Fetch first if it hasn't been.
+func (w *Wishlist) Snapshot() (*Snapshot, error) {
+	return w.SnapshotContext(context.Background())
+}
+
+// SnapshotContext returns everything loaded from this wishlist, loading it
+// with FetchContext first if it hasn't been. When PartialResults is set, a
+// snapshot of whatever was found is returned along with any error.
+func (w *Wishlist) SnapshotContext(ctx context.Context) (*Snapshot, error) {
+	w.mu.RLock()
+	fetched := w.fetched
+	w.mu.RUnlock()
+
+	var err error
+	if !fetched {
+		err = w.FetchContext(ctx)
+	}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if !w.fetched {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{
+		Version:    SnapshotVersion,
+		WishlistID: w.id,
+		Domain:     w.domain,
+		Name:       w.name,
+		PrintURL:   w.printURL,
+		URLs:       make([]string, len(w.urls)),
+		FetchedAt:  w.fetchedAt,
+		Items:      []*SnapshotItem{},
+	}
+	copy(snapshot.URLs, w.urls)
+
+	for _, item := range sortItems(w.items) {
+		snapshot.Items = append(snapshot.Items, newSnapshotItem(item))
+	}
+
+	return snapshot, err
+}
+
+// LoadSnapshot reads a snapshot written as JSON in any version of its schema,
+// such as by Save. The snapshot returned is in the current version.
+func LoadSnapshot(r io.Reader) (*Snapshot, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var header map[string]json.RawMessage
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("Could not read snapshot: %s", err)
+	}
+
+	version := 0
+	if rawVersion, ok := header["version"]; ok {
+		if err := json.Unmarshal(rawVersion, &version); err != nil {
+			return nil, fmt.Errorf("Could not read snapshot version: %s", err)
+		}
+	}
+
+	load, ok := snapshotLoaders[version]
+	if !ok {
+		return nil, fmt.Errorf("Snapshot version %d is not supported, expected at most %d",
+			version, SnapshotVersion)
+	}
+
+	snapshot, err := load(data)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read version %d snapshot: %s", version, err)
+	}
+	snapshot.Version = SnapshotVersion
+	return snapshot, nil
+}
+
+// Save writes the snapshot as JSON in the current version of its schema.
+func (s *Snapshot) Save(w io.Writer) error {
+	if s.Version != SnapshotVersion {
+		return fmt.Errorf("Cannot save a version %d snapshot, expected version %d",
+			s.Version, SnapshotVersion)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s)
+}
+
+// ItemList returns the products in the snapshot in the order Amazon displays
+// them.
+func (s *Snapshot) ItemList() []*Item {
+	m := marketplaceFor(s.Domain)
+	items := make([]*Item, len(s.Items))
+	for i, snapshotItem := range s.Items {
+		items[i] = snapshotItem.item(m)
+	}
+	return items
+}
+
+// ItemMap returns the products in the snapshot keyed by their IDs, as Items
+// returns them, e.g., to pass to Refresh or DiffItems.
+func (s *Snapshot) ItemMap() map[string]*Item {
+	items := map[string]*Item{}
+	for _, item := range s.ItemList() {
+		items[item.ID] = item
+	}
+	return items
+}
+
+// newSnapshotItem returns the given product as it is written in a snapshot.
+func newSnapshotItem(item *Item) *SnapshotItem {
+	snapshotItem := &SnapshotItem{
+		ID:             item.ID,
+		ASIN:           item.ASIN,
+		CanonicalASIN:  item.CanonicalASIN,
+		Position:       item.Position,
+		Name:           item.Name,
+		Byline:         item.Byline,
+		DirectURL:      item.DirectURL,
+		AddToCartURL:   item.AddToCartURL,
+		ImageURL:       item.ImageURL,
+		ReviewsURL:     item.ReviewsURL,
+		Price:          item.Price,
+		PriceAmount:    item.PriceAmount,
+		MaxPriceAmount: item.MaxPriceAmount,
+		Offer:          item.Offer,
+		OffersSummary:  item.OffersSummary,
+		IsPrime:        item.IsPrime,
+		Purchased:      item.Purchased,
+		Priority:       item.Priority.String(),
+		Comment:        item.Comment,
+		Attributes:     item.Attributes,
+		RawDateAdded:   item.RawDateAdded,
+		Rating:         item.Rating,
+		Stars:          item.Stars,
+		ReviewCount:    item.ReviewCount,
+	}
+
+	if item.RequestedCount > -1 {
+		count := item.RequestedCount
+		snapshotItem.RequestedCount = &count
+	}
+	if item.OwnedCount > -1 {
+		count := item.OwnedCount
+		snapshotItem.OwnedCount = &count
+	}
+	if dateAdded, err := item.DateAdded(); err == nil {
+		snapshotItem.DateAdded = dateAdded.Format(snapshotDateLayout)
+	}
+
+	return snapshotItem
+}
+
+// item returns the product written in a snapshot of a wishlist in the given
+// Amazon store.
+func (si *SnapshotItem) item(m *Marketplace) *Item {
+	item := NewItem(si.ID, si.Name, si.DirectURL)
+	item.ASIN = si.ASIN
+	item.CanonicalASIN = si.CanonicalASIN
+	item.Position = si.Position
+	item.Byline = si.Byline
+	item.AddToCartURL = si.AddToCartURL
+	item.ImageURL = si.ImageURL
+	item.ReviewsURL = si.ReviewsURL
+	item.Price = si.Price
+	item.PriceAmount = si.PriceAmount
+	item.MaxPriceAmount = si.MaxPriceAmount
+	item.Offer = si.Offer
+	item.OffersSummary = si.OffersSummary
+	item.IsPrime = si.IsPrime
+	item.Purchased = si.Purchased
+	item.Comment = si.Comment
+	item.Attributes = si.Attributes
+	item.RawDateAdded = si.RawDateAdded
+	item.Rating = si.Rating
+	item.Stars = si.Stars
+	item.ReviewCount = si.ReviewCount
+	item.marketplace = m
+
+	if si.RequestedCount != nil {
+		item.RequestedCount = *si.RequestedCount
+	}
+	if si.OwnedCount != nil {
+		item.OwnedCount = *si.OwnedCount
+	}
+	if priority, ok := ParsePriority(si.Priority); ok {
+		item.Priority = priority
+	} else if number, err := strconv.Atoi(si.Priority); err == nil {
+		item.Priority = Priority(number)
+	}
+
+	return item
+}
+
+// loadSnapshotV0 reads a snapshot written before snapshots were versioned,
+// i.e., the JSON encoding of the map returned by Items. It says nothing of the
+// wishlist itself, so only the snapshot's items are set, and their dates are
+// parsed as amazon.com writes them.
+func loadSnapshotV0(data []byte) (*Snapshot, error) {
+	var items map[string]*Item
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{URLs: []string{}, Items: []*SnapshotItem{}}
+	for id, item := range items {
+		if item == nil {
+			return nil, fmt.Errorf("Item %s is null", id)
+		}
+		if item.ID == "" {
+			item.ID = id
+		}
+		snapshot.Items = append(snapshot.Items, newSnapshotItem(item))
+	}
+	sortSnapshotItems(snapshot.Items)
+
+	return snapshot, nil
+}
+
+// loadSnapshotV1 reads a snapshot written in version 1 of the schema.
+func loadSnapshotV1(data []byte) (*Snapshot, error) {
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+	for _, item := range snapshot.Items {
+		if item == nil {
+			return nil, errors.New("Snapshot contains a null item")
+		}
+	}
+
+	return &snapshot, nil
+}
+
+// sortSnapshotItems orders the given products by their position in the
+// wishlist, and then by ID.
+func sortSnapshotItems(items []*SnapshotItem) {
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Position != items[j].Position {
+			return items[i].Position < items[j].Position
+		}
+		return items[i].ID < items[j].ID
+	})
+}
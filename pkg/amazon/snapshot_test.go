@@ -0,0 +1,158 @@
+package amazon
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func fetchTestSnapshot(t *testing.T, ts *httptest.Server) (*Wishlist, *Snapshot) {
+	wishlist, err := NewWishlistFromIDAtDomain("123abc", ts.URL)
+	require.NoError(t, err)
+	wishlist.CacheResults = false
+
+	snapshot, err := wishlist.Snapshot()
+	require.NoError(t, err)
+	return wishlist, snapshot
+}
+
+func TestWishlistSnapshot(t *testing.T) {
+	ts := newTestServer(t, "123abc")
+	defer ts.Close()
+	wishlist, snapshot := fetchTestSnapshot(t, ts)
+
+	require.Equal(t, SnapshotVersion, snapshot.Version)
+	require.Equal(t, "123abc", snapshot.WishlistID)
+	require.Equal(t, "NHA Wish List", snapshot.Name)
+	require.Equal(t, wishlist.URLs(), snapshot.URLs)
+	require.WithinDuration(t, time.Now(), snapshot.FetchedAt, time.Minute)
+	require.Len(t, snapshot.Items, 1)
+
+	item := snapshot.Items[0]
+	require.Equal(t, "I2G6UJO0FYWV8J", item.ID)
+	require.Equal(t, "B0018CLTKE", item.ASIN)
+	require.Equal(t, &Money{Amount: 1596, Currency: "USD"}, item.PriceAmount)
+	require.Equal(t, 50, *item.RequestedCount)
+	require.Equal(t, 11, *item.OwnedCount)
+	require.Equal(t, "medium", item.Priority)
+	require.Equal(t, "2019-07-10", item.DateAdded)
+	require.Equal(t, 4.0, item.Stars)
+}
+
+func TestWishlistSnapshotEmpty(t *testing.T) {
+	ts := newPagedTestServer(t, "123abc", []string{})
+	defer ts.Close()
+	_, snapshot := fetchTestSnapshot(t, ts.Server)
+
+	var buf bytes.Buffer
+	require.NoError(t, snapshot.Save(&buf))
+	require.Contains(t, buf.String(), `"items": []`)
+
+	snapshot, err := LoadSnapshot(strings.NewReader("{}"))
+	require.NoError(t, err)
+	require.NotNil(t, snapshot.Items)
+	require.Empty(t, snapshot.Items)
+}
+
+func TestWishlistSnapshotPartialResults(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("lek") != "" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(wishlistPageHTML([]string{"I2G6UJO0FYWV8J"}, "/hz/wishlist/ls/123abc?lek=1")))
+	}))
+	defer ts.Close()
+
+	wishlist, err := NewWishlistFromIDAtDomain("123abc", ts.URL)
+	require.NoError(t, err)
+	wishlist.CacheResults = false
+	wishlist.PartialResults = true
+
+	snapshot, err := wishlist.Snapshot()
+	require.True(t, errors.Is(err, ErrNotFound), "should be a not found error: %v", err)
+	require.NotNil(t, snapshot)
+	require.Len(t, snapshot.Items, 1)
+	require.Equal(t, "I2G6UJO0FYWV8J", snapshot.Items[0].ID)
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	ts := newTestServer(t, "123abc")
+	defer ts.Close()
+	wishlist, snapshot := fetchTestSnapshot(t, ts)
+
+	var buf bytes.Buffer
+	require.NoError(t, snapshot.Save(&buf))
+	require.Contains(t, buf.String(), `"version": 1`)
+	require.Contains(t, buf.String(), `"priceAmount": {`)
+
+	loaded, err := LoadSnapshot(&buf)
+	require.NoError(t, err)
+	require.Equal(t, snapshot, loaded)
+
+	items, err := wishlist.Items()
+	require.NoError(t, err)
+	loadedItems := loaded.ItemMap()
+	require.Len(t, loadedItems, len(items))
+	for id, item := range items {
+		require.Equal(t, newSnapshotItem(item), newSnapshotItem(loadedItems[id]))
+		require.Equal(t, item.String(), loadedItems[id].String())
+	}
+
+	dateAdded, err := loadedItems["I2G6UJO0FYWV8J"].DateAdded()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2019, 7, 10, 0, 0, 0, 0, time.UTC), *dateAdded)
+}
+
+func TestLoadSnapshotVersion0(t *testing.T) {
+	ts := newTestServer(t, "123abc")
+	defer ts.Close()
+	wishlist, _ := fetchTestSnapshot(t, ts)
+	items, err := wishlist.Items()
+	require.NoError(t, err)
+	items["I2G6UJO0FYWV8J"].Priority = PriorityHigh
+
+	data, err := json.Marshal(items)
+	require.NoError(t, err)
+
+	snapshot, err := LoadSnapshot(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, SnapshotVersion, snapshot.Version)
+	require.Equal(t, "", snapshot.WishlistID)
+	require.Len(t, snapshot.Items, 1)
+	require.Equal(t, "high", snapshot.Items[0].Priority)
+
+	loaded := snapshot.ItemMap()["I2G6UJO0FYWV8J"]
+	require.Equal(t, newSnapshotItem(items["I2G6UJO0FYWV8J"]), newSnapshotItem(loaded))
+}
+
+func TestLoadSnapshotVersion0NonUSDate(t *testing.T) {
+	data := `{"I2G6UJO0FYWV8J": {"ID": "I2G6UJO0FYWV8J", "RawDateAdded": "10. Juli 2019"}}`
+
+	snapshot, err := LoadSnapshot(strings.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, snapshot.Items, 1)
+	require.Equal(t, "10. Juli 2019", snapshot.Items[0].RawDateAdded)
+	require.Equal(t, "", snapshot.Items[0].DateAdded)
+}
+
+func TestLoadSnapshotInvalid(t *testing.T) {
+	_, err := LoadSnapshot(strings.NewReader(`{"version": 99, "items": []}`))
+	require.EqualError(t, err, "Snapshot version 99 is not supported, expected at most 1")
+
+	_, err = LoadSnapshot(strings.NewReader(`[]`))
+	require.Error(t, err)
+
+	_, err = LoadSnapshot(strings.NewReader(`{"version": 1, "items": [null]}`))
+	require.Error(t, err)
+
+	require.Error(t, (&Snapshot{Version: 0}).Save(&bytes.Buffer{}))
+}
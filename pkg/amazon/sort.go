@@ -0,0 +1,110 @@
+package amazon
+
+import (
+	"fmt"
+)
+
+// SortOrder is an order Amazon can sort the products on a wishlist in before
+// they are loaded, so that e.g. the cheapest products are on the first page.
+type SortOrder string
+
+const (
+	// SortDateAdded lists the most recently added products first, which is
+	// what Amazon shows by default.
+	SortDateAdded SortOrder = "date"
+
+	// SortPriceLowToHigh lists the cheapest products first.
+	SortPriceLowToHigh SortOrder = "universal-price"
+
+	// SortPriceHighToLow lists the most expensive products first.
+	SortPriceHighToLow SortOrder = "universal-price-desc"
+
+	// SortPriority lists the products the wishlist recipient wants most first.
+	SortPriority SortOrder = "priority"
+
+	// SortLastUpdated lists the most recently changed products first.
+	SortLastUpdated SortOrder = "last-updated"
+
+	// SortTitle lists products alphabetically by name.
+	SortTitle SortOrder = "universal-title"
+)
+
+// sortOrders are every order Amazon can sort a wishlist in.
+var sortOrders = []SortOrder{SortDateAdded, SortPriceLowToHigh, SortPriceHighToLow,
+	SortPriority, SortLastUpdated, SortTitle}
+
+// ParseSortOrder returns the SortOrder with the given name, such as
+// "universal-price".
+func ParseSortOrder(name string) (SortOrder, error) {
+	for _, order := range sortOrders {
+		if string(order) == name {
+			return order, nil
+		}
+	}
+	return "", fmt.Errorf("Unknown sort order '%s'", name)
+}
+
+func (o SortOrder) String() string {
+	return string(o)
+}
+
+// Filter narrows down which products on a wishlist Amazon lists, before they
+// are loaded.
+type Filter string
+
+const (
+	// FilterAll lists every product, which is what Amazon shows by default.
+	FilterAll Filter = "DEFAULT"
+
+	// FilterPrime lists only products eligible for Amazon Prime.
+	FilterPrime Filter = "prime"
+)
+
+// filters are every way Amazon can filter a wishlist.
+var filters = []Filter{FilterAll, FilterPrime}
+
+// ParseFilter returns the Filter with the given name, such as "prime".
+func ParseFilter(name string) (Filter, error) {
+	for _, filter := range filters {
+		if string(filter) == name {
+			return filter, nil
+		}
+	}
+	return "", fmt.Errorf("Unknown filter '%s'", name)
+}
+
+func (f Filter) String() string {
+	return string(f)
+}
+
+// listOptions choose which products a wishlist's URL lists, and in what
+// order.
+type listOptions struct {
+	reveal RevealMode
+	sort   SortOrder
+	filter Filter
+}
+
+// defaultListOptions are how Amazon lists a wishlist's products when no
+// options are chosen.
+var defaultListOptions = listOptions{
+	reveal: RevealUnpurchased,
+	sort:   SortDateAdded,
+	filter: FilterAll,
+}
+
+// validate returns an error if any of these options is not one Amazon knows.
+// The options don't vary by store, so there is nothing to check per
+// marketplace.
+func (o *listOptions) validate() error {
+	if _, err := ParseRevealMode(string(o.reveal)); err != nil {
+		return err
+	}
+	if _, err := ParseSortOrder(string(o.sort)); err != nil {
+		return err
+	}
+	if _, err := ParseFilter(string(o.filter)); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package amazon
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSortOrder(t *testing.T) {
+	for _, order := range sortOrders {
+		parsed, err := ParseSortOrder(order.String())
+		require.NoError(t, err)
+		require.Equal(t, order, parsed)
+	}
+
+	_, err := ParseSortOrder("cheapest")
+	require.Error(t, err)
+}
+
+func TestParseFilter(t *testing.T) {
+	filter, err := ParseFilter("prime")
+	require.NoError(t, err)
+	require.Equal(t, FilterPrime, filter)
+
+	_, err = ParseFilter("free-shipping")
+	require.Error(t, err)
+}
+
+func TestWishlistSetSortAndFilter(t *testing.T) {
+	wishlist, err := NewWishlistFromIDAtDomain("123abc", "https://www.amazon.de")
+	require.NoError(t, err)
+	require.Equal(t, SortDateAdded, wishlist.Sort())
+	require.Equal(t, FilterAll, wishlist.Filter())
+
+	require.NoError(t, wishlist.SetSort(SortPriceLowToHigh))
+	require.NoError(t, wishlist.SetFilter(FilterPrime))
+	require.NoError(t, wishlist.SetReveal(RevealAll))
+	require.Equal(t, SortPriceLowToHigh, wishlist.Sort())
+	require.Equal(t, FilterPrime, wishlist.Filter())
+	require.Equal(t, []string{"https://www.amazon.de/hz/wishlist/ls/123abc?reveal=all&sort=universal-price&layout=standard&viewType=list&filter=prime&type=wishlist"},
+		wishlist.URLs())
+
+	require.Error(t, wishlist.SetSort(SortOrder("cheapest")))
+	require.Error(t, wishlist.SetFilter(Filter("free-shipping")))
+	require.Equal(t, SortPriceLowToHigh, wishlist.Sort())
+	require.Equal(t, FilterPrime, wishlist.Filter())
+}
+
+func TestListOptionsValidate(t *testing.T) {
+	options := defaultListOptions
+	require.NoError(t, options.validate())
+
+	options.sort = SortOrder("cheapest")
+	require.EqualError(t, options.validate(), "Unknown sort order 'cheapest'")
+
+	options = defaultListOptions
+	options.filter = Filter("free-shipping")
+	require.EqualError(t, options.validate(), "Unknown filter 'free-shipping'")
+}
+
+func TestItemsSorted(t *testing.T) {
+	var sorts []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hz/wishlist/ls/123abc", func(w http.ResponseWriter, r *http.Request) {
+		sorts = append(sorts, r.URL.Query().Get("sort"))
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(wishlistHTML))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	wishlist, err := NewWishlistFromIDAtDomain("123abc", ts.URL)
+	require.NoError(t, err)
+	wishlist.CacheResults = false
+	require.NoError(t, wishlist.SetSort(SortPriceHighToLow))
+
+	items, err := wishlist.Items()
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, []string{"universal-price-desc"}, sorts)
+}
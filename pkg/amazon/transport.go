@@ -0,0 +1,18 @@
+package amazon
+
+import (
+	"context"
+	"net/http"
+)
+
+// contextTransport is an http.RoundTripper that ties every request it makes
+// to a context, so in-flight requests are abandoned when the context is done.
+type contextTransport struct {
+	ctx       context.Context
+	transport http.RoundTripper
+}
+
+// RoundTrip executes a single HTTP request using the wrapped transport.
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.transport.RoundTrip(req.WithContext(t.ctx))
+}
@@ -1,16 +1,15 @@
 package amazon
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/url"
-	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gocolly/colly"
-	"github.com/gocolly/colly/extensions"
-	"github.com/gocolly/colly/proxy"
 )
 
 const (
@@ -19,17 +18,31 @@ const (
 	DefaultAmazonDomain = "https://www.amazon.com"
 
 	robotMessage         = "we just need to make sure you're not a robot"
+	captchaSelector      = "form[action*='validateCaptcha']"
+	signInPathPrefix     = "/ap/signin"
+	itemListSelector     = "#g-items"
 	cachePath            = "./cache"
 	proxyPrefix          = "socks5://"
 	addToCartText        = "add to cart"
 	reviewCountIDPrefix  = "review_count_"
 	requestCountIDPrefix = "itemRequested_"
 	ownedCountIDPrefix   = "itemPurchased_"
+	priorityIDPrefix     = "itemPriority_"
+	priorityLabelPrefix  = "itemPriorityLabel_"
+	priorityClassPrefix  = "item-priority-"
+	commentIDPrefix      = "itemComment_"
+	bylineIDPrefix       = "item-byline-"
+	usedAndNewIDPrefix   = "used-and-new_"
+	attributeSeparators  = ":："
 	dateAddedIDPrefix    = "itemAddedDate_"
-	dateAddedPrefix      = "Added "
+	positionKey          = "position"
+	asinPrefix           = "ASIN:"
 )
 
-// Wishlist represents an Amazon wishlist of products.
+// Wishlist represents an Amazon wishlist of products. A Wishlist is safe for
+// use by multiple goroutines, e.g., reading its items while another goroutine
+// refreshes them with Fetch, as long as DebugMode, CacheResults,
+// PartialResults, MaxPages and MaxItems are set before it is shared.
 type Wishlist struct {
 	// DebugMode specifies whether messages should be logged to STDOUT about
 	// what's going on, as well as if the HTML source of the wishlist should
@@ -39,13 +52,36 @@ type Wishlist struct {
 	// CacheResults determines whether responses from Amazon should be cached.
 	CacheResults bool
 
-	errors    []error
-	proxyURLs []string
-	urls      []string
-	id        string
-	items     map[string]*Item
-	name      string
-	printURL  string
+	// PartialResults determines whether the products and details that could
+	// be loaded are returned even when some pages or fields could not be. When
+	// set, the error returned alongside them is a *MultiError listing every
+	// problem with the page URL, and the item ID and field where applicable.
+	PartialResults bool
+
+	// MaxPages limits how many pages of the wishlist are loaded, or is 0 to
+	// load every page. NextCursor says where to resume from if it was reached.
+	MaxPages int
+
+	// MaxItems limits how many products are loaded, or is 0 to load every
+	// product. NextCursor says where to resume from if it was reached.
+	MaxItems int
+
+	mu          sync.RWMutex
+	errors      []error
+	warnings    []*FieldError
+	proxyURLs   []string
+	urls        []string
+	id          string
+	domain      string
+	options     listOptions
+	start       *Cursor
+	cursor      *Cursor
+	marketplace *Marketplace
+	items       map[string]*Item
+	name        string
+	printURL    string
+	fetched     bool
+	fetchedAt   time.Time
 }
 
 // NewWishlist constructs an Amazon wishlist for the given URL.
@@ -86,7 +122,7 @@ func NewWishlistFromIDAtDomain(id string, amazonDomain string) (*Wishlist, error
 		return nil, errors.New("No Amazon domain specified")
 	}
 
-	wishlistURL, err := getWishlistURL(amazonDomain, id)
+	wishlistURL, err := getWishlistURL(amazonDomain, id, defaultListOptions)
 	if err != nil {
 		return nil, err
 	}
@@ -96,9 +132,13 @@ func NewWishlistFromIDAtDomain(id string, amazonDomain string) (*Wishlist, error
 		CacheResults: true,
 		urls:         []string{wishlistURL},
 		id:           id,
+		domain:       amazonDomain,
+		options:      defaultListOptions,
+		marketplace:  marketplaceFor(wishlistURL),
 		items:        map[string]*Item{},
 		proxyURLs:    []string{},
 		errors:       []error{},
+		warnings:     []*FieldError{},
 		name:         "",
 		printURL:     "",
 	}, nil
@@ -109,440 +149,409 @@ func (w *Wishlist) ID() string {
 	return w.id
 }
 
-// Name returns the name of this wishlist on Amazon.
-func (w *Wishlist) Name() (string, error) {
-	c := w.collector()
-
-	c.OnHTML("#profile-list-name", w.onName)
-
-	if err := w.loadWishlist(c); err != nil {
-		return "", err
-	}
+// Reveal returns which products this wishlist is loaded with, according to
+// whether they have been bought. Defaults to RevealUnpurchased.
+func (w *Wishlist) Reveal() RevealMode {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
 
-	return w.name, nil
+	return w.options.reveal
 }
 
-// PrintURL returns the URL to the printer-friendly view of this wishlist on Amazon.
-func (w *Wishlist) PrintURL() (string, error) {
-	c := w.collector()
-
-	c.OnHTML("#wl-print-link", w.onPrintLink)
-
-	if err := w.loadWishlist(c); err != nil {
-		return "", err
-	}
-
-	return w.printURL, nil
+// SetReveal chooses which products this wishlist is loaded with, according to
+// whether they have been bought, e.g., RevealAll to include products that were
+// already bought. Anything loaded before is discarded, so the next call to
+// Items, Name or PrintURL loads the wishlist anew.
+func (w *Wishlist) SetReveal(mode RevealMode) error {
+	return w.setOptions(func(options *listOptions) {
+		options.reveal = mode
+	})
 }
 
-// URLs returns the URLs used to access all the items in the wishlist. Will be
-// extended as necessary when Items is called.
-func (w *Wishlist) URLs() []string {
-	return w.urls
-}
+// Sort returns the order Amazon lists this wishlist's products in. Defaults
+// to SortDateAdded.
+func (w *Wishlist) Sort() SortOrder {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
 
-// Errors returns any errors that occurred when trying to load the wishlist.
-func (w *Wishlist) Errors() []error {
-	return w.errors
+	return w.options.sort
 }
 
-// SetProxyURLs specifies URLs of proxies to use when accessing Amazon. May
-// be useful if you're getting an error about Amazon thinking you're a bot.
-func (w *Wishlist) SetProxyURLs(urls ...string) {
-	w.proxyURLs = make([]string, len(urls))
-	for i, url := range urls {
-		if strings.HasPrefix(url, proxyPrefix) {
-			w.proxyURLs[i] = url
-		} else {
-			w.proxyURLs[i] = fmt.Sprintf("%s%s", proxyPrefix, url)
-		}
-	}
-}
-
-// Items returns a map of the products on the wishlist, where keys are
-// the product IDs and the values are the products.
-func (w *Wishlist) Items() (map[string]*Item, error) {
-	c := w.collector()
-
-	c.OnHTML("ul li", w.onListItem)
-	c.OnHTML("a.wl-see-more", func(link *colly.HTMLElement) {
-		w.onLoadMoreLink(c, link)
+// SetSort chooses the order Amazon lists this wishlist's products in, e.g.,
+// SortPriceLowToHigh so the cheapest products are loaded first. An error is
+// returned if the order is not one Amazon knows. Every Amazon store serves the
+// same wishlist pages, so every store accepts every order. Anything loaded
+// before is discarded, so the next call to Items, Name or PrintURL loads the
+// wishlist anew.
+func (w *Wishlist) SetSort(order SortOrder) error {
+	return w.setOptions(func(options *listOptions) {
+		options.sort = order
 	})
+}
 
-	if err := w.loadWishlist(c); err != nil {
-		return nil, err
-	}
+// Filter returns how Amazon narrows down the products on this wishlist.
+// Defaults to FilterAll.
+func (w *Wishlist) Filter() Filter {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
 
-	return w.items, nil
+	return w.options.filter
 }
 
-func (w *Wishlist) String() string {
-	return strings.Join(w.urls, ", ")
+// SetFilter chooses how Amazon narrows down the products on this wishlist,
+// e.g., FilterPrime for only products eligible for Prime. An error is returned
+// if the filter is not one Amazon knows. Every Amazon store accepts every
+// filter, as with SetSort. Anything loaded before is discarded, so the next
+// call to Items, Name or PrintURL loads the wishlist anew.
+func (w *Wishlist) SetFilter(filter Filter) error {
+	return w.setOptions(func(options *listOptions) {
+		options.filter = filter
+	})
 }
 
-func (w *Wishlist) loadWishlist(c *colly.Collector) error {
-	if w.DebugMode {
-		fmt.Println("Using URL", w.urls[0])
-	}
+// setOptions changes how Amazon lists this wishlist's products, discarding
+// anything loaded with the previous options.
+func (w *Wishlist) setOptions(change func(*listOptions)) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
 
-	if err := c.Visit(w.urls[0]); err != nil {
+	options := w.options
+	change(&options)
+	if err := options.validate(); err != nil {
 		return err
 	}
 
-	c.Wait()
-
-	if len(w.errors) > 0 {
-		return w.errors[0]
+	wishlistURL, err := getWishlistURL(w.domain, w.id, options)
+	if err != nil {
+		return err
 	}
 
+	w.options = options
+	w.start = nil
+	w.reset(wishlistURL)
 	return nil
 }
 
-func (w *Wishlist) collector() *colly.Collector {
-	options := []func(*colly.Collector){colly.Async(true)}
-	if w.CacheResults {
-		if w.DebugMode {
-			fmt.Println("Caching Amazon responses in", cachePath)
-		}
-		options = append(options, colly.CacheDir(cachePath))
-	}
-	c := colly.NewCollector(options...)
+// NextCursor returns where the last crawl of this wishlist stopped because it
+// reached MaxPages or MaxItems, or nil if it loaded the rest of the wishlist.
+func (w *Wishlist) NextCursor() *Cursor {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
 
-	extensions.RandomUserAgent(c)
-	c.Limit(&colly.LimitRule{
-		RandomDelay: 2 * time.Second,
-		Parallelism: 4,
-	})
-
-	if len(w.proxyURLs) > 0 {
-		w.applyProxies(c)
+	if w.cursor == nil {
+		return nil
 	}
+	cursor := *w.cursor
+	return &cursor
+}
 
-	c.OnRequest(w.onRequest)
-	c.OnResponse(w.onResponse)
-	c.OnError(func(r *colly.Response, e error) {
-		w.errors = append(w.errors, e)
-	})
+// Resume makes the next crawl of this wishlist start where a previous one
+// stopped, as given by NextCursor, rather than from its first page. The
+// positions of the products loaded continue from those loaded before. A nil
+// cursor starts from the first page again. Anything loaded before is
+// discarded, so the next call to Items, Name or PrintURL loads the wishlist
+// anew.
+func (w *Wishlist) Resume(cursor *Cursor) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
 
-	return c
-}
+	if cursor == nil {
+		wishlistURL, err := getWishlistURL(w.domain, w.id, w.options)
+		if err != nil {
+			return err
+		}
 
-func (w *Wishlist) onRequest(r *colly.Request) {
-	if w.DebugMode {
-		fmt.Println("Using User-Agent", r.Headers.Get("User-Agent"))
+		w.start = nil
+		w.reset(wishlistURL)
+		return nil
 	}
-	r.Headers.Set("cookie", "i18n-prefs=USD")
-}
 
-func (w *Wishlist) onResponse(r *colly.Response) {
-	if w.DebugMode {
-		fmt.Printf("Status %d\n", r.StatusCode)
+	uri, err := url.Parse(cursor.URL)
+	if err != nil {
+		return err
 	}
-
-	if strings.Contains(string(r.Body), robotMessage) {
-		w.errors = append(w.errors, errors.New("Amazon is not showing the wishlist because it thinks I'm a robot :("))
+	if !uri.IsAbs() {
+		return fmt.Errorf("Cursor URL '%s' is not an absolute URL to an Amazon wishlist page",
+			cursor.URL)
 	}
 
-	if w.DebugMode {
-		filename := fmt.Sprintf("wishlist-%s-%s.html", w.id, r.FileName())
-		fmt.Printf("Saving wishlist HTML source to %s...\n", filename)
-		if err := r.Save(filename); err != nil {
-			w.errors = append(w.errors, err)
-		}
-	}
+	start := *cursor
+	w.start = &start
+	w.reset(cursor.URL)
+	return nil
 }
 
-func (w *Wishlist) onName(el *colly.HTMLElement) {
-	w.name = strings.TrimSpace(el.Text)
+// reset discards anything loaded before, so the wishlist will next be loaded
+// from the given URL. The caller must hold the lock.
+func (w *Wishlist) reset(wishlistURL string) {
+	w.urls = []string{wishlistURL}
+	w.items = map[string]*Item{}
+	w.errors = []error{}
+	w.warnings = []*FieldError{}
+	w.cursor = nil
+	w.name = ""
+	w.printURL = ""
+	w.fetched = false
 }
 
-func (w *Wishlist) onPrintLink(link *colly.HTMLElement) {
-	relativeURL := link.Attr("href")
-	if len(relativeURL) < 1 {
-		return
-	}
+// Marketplace returns the Amazon store this wishlist is on. Wishlists on
+// domains not listed in Marketplaces are assumed to be written like those at
+// DefaultAmazonDomain.
+func (w *Wishlist) Marketplace() Marketplace {
+	return *w.marketplace
+}
 
-	w.printURL = link.Request.AbsoluteURL(relativeURL)
+// Name returns the name of this wishlist on Amazon.
+func (w *Wishlist) Name() (string, error) {
+	return w.NameContext(context.Background())
 }
 
-func (w *Wishlist) onLoadMoreLink(c *colly.Collector, link *colly.HTMLElement) {
-	relativeURL := link.Attr("href")
-	if len(relativeURL) < 1 {
-		return
+// NameContext returns the name of this wishlist on Amazon. If the context is
+// done before the wishlist has loaded, the context's error is returned.
+func (w *Wishlist) NameContext(ctx context.Context) (string, error) {
+	w.mu.RLock()
+	if w.fetched {
+		defer w.mu.RUnlock()
+		return w.name, nil
 	}
+	w.mu.RUnlock()
 
-	nextPageURL := link.Request.AbsoluteURL(relativeURL)
-	w.urls = append(w.urls, nextPageURL)
+	s := w.scraper(ctx)
+	c := s.collector()
 
-	if w.DebugMode {
-		fmt.Println("Found URL to next page", nextPageURL)
-	}
+	c.OnHTML("#profile-list-name", s.onName)
 
-	c.Visit(nextPageURL)
-}
-
-func (w *Wishlist) onListItem(listItem *colly.HTMLElement) {
-	id := listItem.Attr("data-itemid")
-	if len(id) < 1 {
-		return
+	err := s.load(c)
+	w.setErrors(s.errors)
+	if !s.keepResults(err) {
+		return "", err
 	}
 
-	listItem.ForEach("a", func(index int, link *colly.HTMLElement) {
-		w.onLink(id, link)
-	})
-	listItem.ForEach(".a-price", func(index int, priceEl *colly.HTMLElement) {
-		w.onPrice(id, priceEl)
-	})
-	listItem.ForEach(".itemUsedAndNewPrice", func(index int, priceEl *colly.HTMLElement) {
-		w.onBackupPrice(id, priceEl)
-	})
-	listItem.ForEach(".dateAddedText", func(index int, container *colly.HTMLElement) {
-		w.onDateAddedContainer(id, container)
-	})
-	listItem.ForEach("[data-action='add-to-cart']", func(index int, container *colly.HTMLElement) {
-		w.onAddToCartContainer(id, container)
-	})
-	listItem.ForEach(".g-itemImage", func(index int, container *colly.HTMLElement) {
-		w.onImageContainer(id, container)
-	})
-	listItem.ForEach(".reviewStarsPopoverLink", func(index int, container *colly.HTMLElement) {
-		w.onRatingContainer(id, container)
-	})
-	listItem.ForEach(".a-icon-prime", func(index int, primeIndicator *colly.HTMLElement) {
-		w.onPrime(id, primeIndicator)
-	})
-	listItem.ForEach("span", func(index int, span *colly.HTMLElement) {
-		w.onSpan(id, span)
-	})
+	return s.name, err
 }
 
-func (w *Wishlist) onSpan(id string, span *colly.HTMLElement) {
-	spanID := span.Attr("id")
-	if len(spanID) < 1 {
-		return
-	}
-
-	if strings.HasPrefix(spanID, requestCountIDPrefix) {
-		w.onRequestedCountSpan(id, span)
-	} else if strings.HasPrefix(spanID, ownedCountIDPrefix) {
-		w.onOwnedCountSpan(id, span)
-	}
+// PrintURL returns the URL to the printer-friendly view of this wishlist on Amazon.
+func (w *Wishlist) PrintURL() (string, error) {
+	return w.PrintURLContext(context.Background())
 }
 
-func (w *Wishlist) onRequestedCountSpan(id string, span *colly.HTMLElement) {
-	item := w.items[id]
-	if item == nil {
-		return
+// PrintURLContext returns the URL to the printer-friendly view of this
+// wishlist on Amazon. If the context is done before the wishlist has loaded,
+// the context's error is returned.
+func (w *Wishlist) PrintURLContext(ctx context.Context) (string, error) {
+	w.mu.RLock()
+	if w.fetched {
+		defer w.mu.RUnlock()
+		return w.printURL, nil
 	}
+	w.mu.RUnlock()
 
-	requestedCountStr := span.Text
-	if len(requestedCountStr) < 1 {
-		return
-	}
+	s := w.scraper(ctx)
+	c := s.collector()
 
-	requestedCount, err := strconv.ParseInt(requestedCountStr, 10, 64)
-	if err != nil {
-		w.errors = append(w.errors, err)
-		return
+	c.OnHTML("#wl-print-link", s.onPrintLink)
+
+	err := s.load(c)
+	w.setErrors(s.errors)
+	if !s.keepResults(err) {
+		return "", err
 	}
 
-	item.RequestedCount = int(requestedCount)
+	return s.printURL, err
 }
 
-func (w *Wishlist) onOwnedCountSpan(id string, span *colly.HTMLElement) {
-	item := w.items[id]
-	if item == nil {
-		return
-	}
+// Fetch loads every page of this wishlist from Amazon in a single crawl.
+// Afterwards, Name, PrintURL and Items return what was found without making
+// further requests. Call Fetch again to refresh the wishlist.
+func (w *Wishlist) Fetch() error {
+	return w.FetchContext(context.Background())
+}
 
-	ownedCountStr := span.Text
-	if len(ownedCountStr) < 1 {
-		return
-	}
+// FetchContext loads every page of this wishlist from Amazon in a single
+// crawl. Until the crawl finishes, the results of any previous crawl remain
+// available.
+//
+// If the context is done before every page has loaded, no further pages are
+// requested and the context's error is returned. Whatever was found so far is
+// kept. After this or any other error, the next call to Name, PrintURL or
+// Items loads the wishlist again, unless PartialResults is set and the
+// context is not done.
+func (w *Wishlist) FetchContext(ctx context.Context) error {
+	s := w.scraper(ctx)
+	c := s.collector()
 
-	ownedCount, err := strconv.ParseInt(ownedCountStr, 10, 64)
-	if err != nil {
-		w.errors = append(w.errors, err)
-		return
-	}
+	c.OnHTML("#profile-list-name", s.onName)
+	c.OnHTML("#wl-print-link", s.onPrintLink)
+	c.OnHTML("ul li", s.onListItem)
+	c.OnHTML("a.wl-see-more", func(link *colly.HTMLElement) {
+		s.onLoadMoreLink(c, link)
+	})
 
-	item.OwnedCount = int(ownedCount)
-}
+	err := s.load(c)
 
-func (w *Wishlist) onPrime(id string, primeIndicator *colly.HTMLElement) {
-	item := w.items[id]
-	if item == nil {
-		return
-	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
 
-	item.IsPrime = true
-}
+	w.urls = s.urls
+	w.items = s.items
+	w.errors = s.errors
+	w.warnings = s.warnings
+	w.cursor = s.cursor
+	w.name = s.name
+	w.printURL = s.printURL
+	w.fetched = err == nil || (s.partialResults && ctx.Err() == nil)
+	w.fetchedAt = time.Now().UTC().Round(0)
 
-func (w *Wishlist) onRatingContainer(id string, container *colly.HTMLElement) {
-	container.ForEach(".a-icon-alt", func(index int, ratingEl *colly.HTMLElement) {
-		w.onRating(id, ratingEl)
-	})
+	return err
 }
 
-func (w *Wishlist) onRating(id string, ratingEl *colly.HTMLElement) {
-	item := w.items[id]
-	if item == nil {
-		return
-	}
-
-	item.Rating = strings.TrimSpace(ratingEl.Text)
-}
+// URLs returns the URLs used to access all the items in the wishlist. Will be
+// extended as necessary when Items is called.
+func (w *Wishlist) URLs() []string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
 
-func (w *Wishlist) onImageContainer(id string, container *colly.HTMLElement) {
-	container.ForEach("img", func(index int, image *colly.HTMLElement) {
-		w.onImage(id, image)
-	})
+	urls := make([]string, len(w.urls))
+	copy(urls, w.urls)
+	return urls
 }
 
-func (w *Wishlist) onImage(id string, image *colly.HTMLElement) {
-	item := w.items[id]
-	if item == nil {
-		return
-	}
-
-	relativeURL := image.Attr("src")
-	if len(relativeURL) < 1 {
-		return
-	}
+// Errors returns any errors that occurred when trying to load the wishlist.
+func (w *Wishlist) Errors() []error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
 
-	item.ImageURL = image.Request.AbsoluteURL(relativeURL)
+	errs := make([]error, len(w.errors))
+	copy(errs, w.errors)
+	return errs
 }
 
-func (w *Wishlist) onAddToCartContainer(id string, container *colly.HTMLElement) {
-	container.ForEach("a", func(index int, link *colly.HTMLElement) {
-		w.onAddToCartLink(id, link)
-	})
-}
+// Warnings returns details of products that could not be parsed the last time
+// the wishlist's products were loaded, such as an unrecognized review count.
+// Unlike Errors, these do not prevent the products from being returned.
+func (w *Wishlist) Warnings() []*FieldError {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
 
-func (w *Wishlist) onAddToCartLink(id string, link *colly.HTMLElement) {
-	linkText := strings.ToLower(link.Text)
-	if !strings.Contains(linkText, addToCartText) {
-		return
-	}
+	warnings := make([]*FieldError, len(w.warnings))
+	copy(warnings, w.warnings)
+	return warnings
+}
 
-	item := w.items[id]
-	if item == nil {
-		return
+// SetProxyURLs specifies URLs of proxies to use when accessing Amazon. May
+// be useful if you're getting an error about Amazon thinking you're a bot.
+func (w *Wishlist) SetProxyURLs(urls ...string) {
+	proxyURLs := make([]string, len(urls))
+	for i, url := range urls {
+		if strings.HasPrefix(url, proxyPrefix) {
+			proxyURLs[i] = url
+		} else {
+			proxyURLs[i] = fmt.Sprintf("%s%s", proxyPrefix, url)
+		}
 	}
 
-	relativeURL := link.Attr("href")
-	if len(relativeURL) < 1 {
-		return
-	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
 
-	item.AddToCartURL = link.Request.AbsoluteURL(relativeURL)
+	w.proxyURLs = proxyURLs
 }
 
-func (w *Wishlist) onReviewCountLink(id string, link *colly.HTMLElement) {
-	item := w.items[id]
-	if item == nil {
-		return
-	}
-
-	reviewCountStr := strings.TrimSpace(link.Text)
-	if reviewCountStr != "" {
-		reviewCountStr = strings.Replace(reviewCountStr, ",", "", -1)
-		reviewCountStr = strings.Replace(reviewCountStr, ".", "", -1)
-		reviewCount, err := strconv.ParseInt(reviewCountStr, 10, 64)
-		if err != nil {
-			w.errors = append(w.errors, err)
-			return
+// Items returns a map of the products on the wishlist, where keys are
+// the product IDs and the values are the products.
+func (w *Wishlist) Items() (map[string]*Item, error) {
+	return w.ItemsContext(context.Background())
+}
+
+// ItemsContext returns a map of the products on the wishlist, where keys are
+// the product IDs and the values are the products. If the context is done
+// before every page of the wishlist has loaded, no further pages are
+// requested and the products found so far are returned along with the
+// context's error. Other errors discard the products found unless
+// PartialResults is set.
+func (w *Wishlist) ItemsContext(ctx context.Context) (map[string]*Item, error) {
+	w.mu.RLock()
+	if w.fetched {
+		defer w.mu.RUnlock()
+		items := make(map[string]*Item, len(w.items))
+		for id, item := range w.items {
+			items[id] = item
 		}
-
-		item.ReviewCount = int(reviewCount)
+		return items, nil
 	}
+	w.mu.RUnlock()
 
-	relativeURL := link.Attr("href")
-	if relativeURL != "" {
-		item.ReviewsURL = link.Request.AbsoluteURL(relativeURL)
-	}
-}
+	s := w.scraper(ctx)
+	c := s.collector()
 
-func (w *Wishlist) onLink(id string, link *colly.HTMLElement) {
-	linkID := link.Attr("id")
-	if len(linkID) > 0 && strings.HasPrefix(linkID, reviewCountIDPrefix) {
-		w.onReviewCountLink(id, link)
-		return
-	}
-
-	title := link.Attr("title")
-	if len(title) < 1 {
-		return
-	}
+	c.OnHTML("ul li", s.onListItem)
+	c.OnHTML("a.wl-see-more", func(link *colly.HTMLElement) {
+		s.onLoadMoreLink(c, link)
+	})
 
-	relativeURL := link.Attr("href")
-	if len(relativeURL) < 1 {
-		return
-	}
+	err := s.load(c)
 
-	w.items[id] = NewItem(id, title, link.Request.AbsoluteURL(relativeURL))
-}
+	w.mu.Lock()
+	w.urls = s.urls
+	w.errors = s.errors
+	w.warnings = s.warnings
+	w.cursor = s.cursor
+	w.mu.Unlock()
 
-func (w *Wishlist) onPrice(id string, priceEl *colly.HTMLElement) {
-	item := w.items[id]
-	if item == nil {
-		return
+	if !s.keepResults(err) {
+		return nil, err
 	}
 
-	item.Price = priceEl.ChildText(".a-offscreen")
+	return s.items, err
 }
 
-func (w *Wishlist) onBackupPrice(id string, priceEl *colly.HTMLElement) {
-	item := w.items[id]
-	if item == nil {
-		return
-	}
+// ItemList returns the products on the wishlist in the order Amazon displays
+// them, across all pages of the wishlist.
+func (w *Wishlist) ItemList() ([]*Item, error) {
+	return w.ItemListContext(context.Background())
+}
 
-	if item.Price != "" {
-		return
+// ItemListContext returns the products on the wishlist in the order Amazon
+// displays them, across all pages of the wishlist. If the context is done
+// before every page of the wishlist has loaded, the products found so far are
+// returned along with the context's error.
+func (w *Wishlist) ItemListContext(ctx context.Context) ([]*Item, error) {
+	items, err := w.ItemsContext(ctx)
+	if items == nil {
+		return nil, err
 	}
 
-	item.Price = priceEl.Text
+	return sortItems(items), err
 }
 
-func (w *Wishlist) onDateAddedContainer(id string, container *colly.HTMLElement) {
-	container.ForEach("span", func(index int, span *colly.HTMLElement) {
-		spanID := span.Attr("id")
-		if len(spanID) < 1 {
-			return
-		}
-		if !strings.HasPrefix(spanID, dateAddedIDPrefix) {
-			return
-		}
-		w.onDateAdded(id, span)
-	})
+func (w *Wishlist) String() string {
+	return strings.Join(w.URLs(), ", ")
 }
 
-func (w *Wishlist) onDateAdded(id string, dateEl *colly.HTMLElement) {
-	item := w.items[id]
-	if item == nil {
-		return
-	}
+// scraper constructs a scraper for a single crawl of this wishlist.
+func (w *Wishlist) scraper(ctx context.Context) *scraper {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
 
-	item.RawDateAdded = strings.TrimPrefix(dateEl.Text, dateAddedPrefix)
+	s := newScraper(ctx, w.id, w.urls[0])
+	s.debugMode = w.DebugMode
+	s.cacheResults = w.CacheResults
+	s.partialResults = w.PartialResults
+	s.proxyURLs = w.proxyURLs
+	s.reveal = w.options.reveal
+	s.maxPages = w.MaxPages
+	s.maxItems = w.MaxItems
+	s.start = w.start
+	return s
 }
 
-func (w *Wishlist) applyProxies(c *colly.Collector) error {
-	if w.DebugMode {
-		fmt.Printf("Using proxies: %v\n", w.proxyURLs)
-	}
+func (w *Wishlist) setErrors(errs []error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
 
-	proxySwitcher, err := proxy.RoundRobinProxySwitcher(w.proxyURLs...)
-	if err != nil {
-		return err
-	}
-
-	c.SetProxyFunc(proxySwitcher)
-
-	return nil
+	w.errors = errs
 }
 
-func getWishlistURL(amazonDomain string, id string) (string, error) {
+func getWishlistURL(amazonDomain string, id string, options listOptions) (string, error) {
 	amazonURL, err := url.Parse(amazonDomain)
 	if err != nil {
 		return "", err
@@ -553,7 +562,7 @@ func getWishlistURL(amazonDomain string, id string) (string, error) {
 		port = ":" + port
 	}
 
-	url := fmt.Sprintf("%s://%s%s/hz/wishlist/ls/%s?reveal=unpurchased&sort=date&layout=standard&viewType=list&filter=DEFAULT&type=wishlist",
-		amazonURL.Scheme, amazonURL.Hostname(), port, id)
+	url := fmt.Sprintf("%s://%s%s/hz/wishlist/ls/%s?reveal=%s&sort=%s&layout=standard&viewType=list&filter=%s&type=wishlist",
+		amazonURL.Scheme, amazonURL.Hostname(), port, id, options.reveal, options.sort, options.filter)
 	return url, nil
 }
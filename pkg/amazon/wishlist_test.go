@@ -1,11 +1,18 @@
 package amazon
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -98,6 +105,8 @@ func TestItems(t *testing.T) {
 	require.Equal(t, itemID, item.ID)
 	require.Equal(t, "Purina Tidy Cats Non-Clumping Cat Litter", item.Name)
 	require.Equal(t, "$15.96", item.Price)
+	require.Equal(t, &Money{Amount: 1596, Currency: "USD"}, item.PriceAmount)
+	require.Equal(t, item.PriceAmount, item.MaxPriceAmount)
 	require.Equal(t, "July 10, 2019", item.RawDateAdded)
 	dateAdded, err := item.DateAdded()
 	require.NoError(t, err)
@@ -116,6 +125,154 @@ func TestItems(t *testing.T) {
 	require.Equal(t, ts.URL+"/dp/B0018CLTKE/?coliid=I2G6UJO0FYWV8J&colid=3I6EQPZ8OB1DT&psc=1&ref_=lv_vv_lig_dp_it", item.DirectURL)
 }
 
+func TestFetch(t *testing.T) {
+	id := "123abc"
+	var requestCount int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(wishlistHTML))
+	}))
+	defer ts.Close()
+
+	wishlist, err := NewWishlistFromIDAtDomain(id, ts.URL)
+	require.NoError(t, err)
+	wishlist.CacheResults = false
+
+	err = wishlist.Fetch()
+	require.NoError(t, err)
+	require.Equal(t, int32(1), atomic.LoadInt32(&requestCount))
+
+	name, err := wishlist.Name()
+	require.NoError(t, err)
+	require.Equal(t, "NHA Wish List", name)
+
+	printURL, err := wishlist.PrintURL()
+	require.NoError(t, err)
+	require.Equal(t, ts.URL+"/hz/wishlist/printview/3I6EQPZ8OB1DT", printURL)
+
+	items, err := wishlist.Items()
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Contains(t, items, "I2G6UJO0FYWV8J")
+
+	require.Len(t, wishlist.URLs(), 1)
+	require.Empty(t, wishlist.Errors())
+	require.Equal(t, int32(1), atomic.LoadInt32(&requestCount), "should not request the wishlist again")
+}
+
+func TestItemsMultiplePages(t *testing.T) {
+	id := "123abc"
+	itemIDs := []string{"I2G6UJO0FYWV8J", "I1BXZQ7XJ0DSLV", "I3K2PLM9XQ0AAB"}
+	ts := newPagedTestServer(t, id, singleItemPages(itemIDs...)...)
+	defer ts.Close()
+
+	wishlist, err := NewWishlistFromIDAtDomain(id, ts.URL)
+	require.NoError(t, err)
+	wishlist.CacheResults = false
+
+	items, err := wishlist.Items()
+	require.NoError(t, err)
+	require.Len(t, items, len(itemIDs))
+	for _, itemID := range itemIDs {
+		require.Contains(t, items, itemID)
+	}
+	require.Len(t, wishlist.URLs(), len(itemIDs))
+}
+
+func TestItemList(t *testing.T) {
+	id := "123abc"
+	itemIDs := []string{"I2G6UJO0FYWV8J", "I1BXZQ7XJ0DSLV", "I3K2PLM9XQ0AAB"}
+	ts := newPagedTestServer(t, id, singleItemPages(itemIDs...)...)
+	defer ts.Close()
+
+	wishlist, err := NewWishlistFromIDAtDomain(id, ts.URL)
+	require.NoError(t, err)
+	wishlist.CacheResults = false
+
+	items, err := wishlist.ItemList()
+	require.NoError(t, err)
+	require.Len(t, items, len(itemIDs))
+	for i, item := range items {
+		require.Equal(t, itemIDs[i], item.ID)
+		require.Equal(t, i, item.Position)
+	}
+}
+
+func TestWishlistConcurrentUse(t *testing.T) {
+	id := "123abc"
+	itemIDs := []string{"I2G6UJO0FYWV8J", "I1BXZQ7XJ0DSLV", "I3K2PLM9XQ0AAB"}
+	ts := newPagedTestServer(t, id, singleItemPages(itemIDs...)...)
+	defer ts.Close()
+
+	wishlist, err := NewWishlistFromIDAtDomain(id, ts.URL)
+	require.NoError(t, err)
+	wishlist.CacheResults = false
+
+	require.NoError(t, wishlist.Fetch())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, wishlist.Fetch())
+		}()
+		go func() {
+			defer wg.Done()
+			items, err := wishlist.Items()
+			assert.NoError(t, err)
+			assert.Len(t, items, len(itemIDs))
+			for _, item := range items {
+				assert.NotEmpty(t, item.Name)
+			}
+			assert.NotEmpty(t, wishlist.URLs())
+			assert.NotEmpty(t, wishlist.String())
+			assert.Empty(t, wishlist.Errors())
+		}()
+	}
+	wg.Wait()
+}
+
+func TestItemsContextCanceled(t *testing.T) {
+	id := "123abc"
+	ts := newTestServer(t, id)
+	defer ts.Close()
+
+	wishlist, err := NewWishlistFromIDAtDomain(id, ts.URL)
+	require.NoError(t, err)
+	wishlist.CacheResults = false
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	items, err := wishlist.ItemsContext(ctx)
+	require.Equal(t, context.Canceled, err)
+	require.Empty(t, items)
+}
+
+func TestItemsContextDeadline(t *testing.T) {
+	id := "123abc"
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	wishlist, err := NewWishlistFromIDAtDomain(id, ts.URL)
+	require.NoError(t, err)
+	wishlist.CacheResults = false
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = wishlist.ItemsContext(ctx)
+	require.Equal(t, context.DeadlineExceeded, err)
+	require.True(t, time.Since(start) < 5*time.Second, "should stop waiting once the deadline passes")
+}
+
 const wishlistHTML = `<!doctype html>
 <html>
 	<body>
@@ -219,6 +376,97 @@ const wishlistHTML = `<!doctype html>
   </body>
 </html>`
 
+// wishlistPageHTML returns the HTML of a wishlist page listing a product for
+// each of the given IDs, linking to the given next page if any.
+func wishlistPageHTML(itemIDs []string, nextPageURL string) string {
+	start := strings.Index(wishlistHTML, "<li ")
+	end := strings.Index(wishlistHTML, "</li>") + len("</li>")
+	listItem := wishlistHTML[start:end]
+
+	listItems := make([]string, len(itemIDs))
+	for i, itemID := range itemIDs {
+		listItems[i] = strings.Replace(listItem, "I2G6UJO0FYWV8J", itemID, -1)
+	}
+
+	html := wishlistHTML[:start] + strings.Join(listItems, "\n") + wishlistHTML[end:]
+	if nextPageURL != "" {
+		html = strings.Replace(html, "</ul>",
+			`</ul><a class="wl-see-more" href="`+nextPageURL+`">See more</a>`, 1)
+	}
+	return html
+}
+
+// singleItemPages returns one page per given item ID, for use with
+// newPagedTestServer.
+func singleItemPages(itemIDs ...string) [][]string {
+	pages := make([][]string, len(itemIDs))
+	for i, itemID := range itemIDs {
+		pages[i] = []string{itemID}
+	}
+	return pages
+}
+
+// pagedTestServer serves a wishlist with a page for each list of item IDs,
+// where pages after the first are requested with a "lek". Its pages can be
+// changed between requests, and it counts the requests made for them.
+type pagedTestServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	pages    [][]string
+	requests int
+}
+
+// newPagedTestServer serves the wishlist with the given ID with a page for
+// each given list of item IDs.
+func newPagedTestServer(t *testing.T, wishlistID string, pages ...[]string) *pagedTestServer {
+	ts := &pagedTestServer{pages: pages}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hz/wishlist/ls/"+wishlistID, ts.servePage)
+	ts.Server = httptest.NewServer(mux)
+
+	return ts
+}
+
+// setPages replaces the pages served and resets the request count.
+func (ts *pagedTestServer) setPages(pages ...[]string) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	ts.pages = pages
+	ts.requests = 0
+}
+
+// requestCount returns how many pages were requested since the server
+// started or its pages were last set.
+func (ts *pagedTestServer) requestCount() int {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	return ts.requests
+}
+
+func (ts *pagedTestServer) servePage(w http.ResponseWriter, r *http.Request) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	ts.requests++
+	page, _ := strconv.Atoi(r.URL.Query().Get("lek"))
+	if page >= len(ts.pages) {
+		http.NotFound(w, r)
+		return
+	}
+
+	nextPageURL := ""
+	if page+1 < len(ts.pages) {
+		nextPageURL = fmt.Sprintf("%s?lek=%d&paginationToken=token%d", r.URL.Path, page+1, page+1)
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	w.Write([]byte(wishlistPageHTML(ts.pages[page], nextPageURL)))
+}
+
 func newTestServer(t *testing.T, wishlistID string) *httptest.Server {
 	mux := http.NewServeMux()
 